@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ComputeAnswer menghitung nilai akhir jawaban dari Cells dan Aggregator.
+// TAPAS hanya memilih cell dan agregator, nilai agregasinya dihitung di sini.
+func ComputeAnswer(resp Response) (string, bool) {
+	aggregator := strings.ToUpper(strings.TrimSpace(resp.Aggregator))
+	switch aggregator {
+	case "", "NONE":
+		if len(resp.Cells) == 0 {
+			return "", false
+		}
+		return strings.Join(resp.Cells, ", "), true
+	case "COUNT":
+		return strconv.Itoa(len(resp.Cells)), true
+	}
+
+	values := make([]float64, 0, len(resp.Cells))
+	for _, cell := range resp.Cells {
+		v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		if err != nil {
+			return "", false
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return "", false
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	switch aggregator {
+	case "SUM":
+		return formatNumber(sum), true
+	case "AVERAGE":
+		return formatNumber(sum / float64(len(values))), true
+	}
+	return "", false
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// AnswersMatch membandingkan dua jawaban; angka dianggap sama jika selisihnya di bawah 1%
+func AnswersMatch(got, expected string) bool {
+	got, expected = normalizeAnswer(got), normalizeAnswer(expected)
+	if got == expected {
+		return true
+	}
+	g, err1 := strconv.ParseFloat(got, 64)
+	e, err2 := strconv.ParseFloat(expected, 64)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	if e == 0 {
+		return math.Abs(g) < 1e-9
+	}
+	return math.Abs(g-e)/math.Abs(e) < 0.01
+}
+
+func normalizeAnswer(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	// Jawaban HF kadang diawali agregator, misalnya "SUM > 1.2, 3.4"
+	if i := strings.Index(s, ">"); i >= 0 {
+		s = strings.TrimSpace(s[i+1:])
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIServer struct HTTP API yang memakai registry, auth, dan pipeline yang sama dengan server gRPC
+type APIServer struct {
+	QA *TableQAServer
+}
+
+// NewAPIServer membuat HTTP API di atas TableQAServer
+func NewAPIServer(qa *TableQAServer) *APIServer {
+	return &APIServer{QA: qa}
+}
+
+// Handler mengembalikan http.Handler dengan semua endpoint /v1
+func (s *APIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ask", s.withAuth(s.handleAsk))
+	mux.HandleFunc("/v1/history", s.withAuth(s.handleHistory))
+	mux.HandleFunc("/v1/feedback", s.withAuth(s.handleFeedback))
+	mux.HandleFunc("/v1/suggestions", s.withAuth(s.handleSuggestions))
+	return mux
+}
+
+func (s *APIServer) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithPrincipal(r.Context(), LocalPrincipal)
+		if s.QA.Auth != nil {
+			credential := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if credential == "" {
+				credential = r.Header.Get("X-API-Key")
+			}
+			p, err := s.QA.Auth.Authenticate(credential)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			ctx = WithPrincipal(r.Context(), p)
+		}
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (s *APIServer) handleAsk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, T("error.method_not_allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+	var req AskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": T("error.invalid_json", err)})
+		return
+	}
+	resp, err := s.QA.Ask(r.Context(), &req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleHistory mencari history milik tenant pemanggil: /v1/history?q=kitchen&limit=20&since=2024-01-01T00:00:00Z
+func (s *APIServer) handleHistory(w http.ResponseWriter, r *http.Request) {
+	history := s.QA.Pipeline.History
+	if history == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": ErrNoHistory.Error()})
+		return
+	}
+
+	principal, _ := PrincipalFromContext(r.Context())
+	q := HistoryQuery{Text: r.URL.Query().Get("q"), Tenant: principal.Tenant, Limit: 50}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": T("error.invalid_limit")})
+			return
+		}
+		q.Limit = n
+	}
+	if since := r.URL.Query().Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": T("error.invalid_since")})
+			return
+		}
+		q.Since = t
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"entries": history.Search(q)})
+}
+
+// handleSuggestions mengembalikan contoh pertanyaan untuk dataset: /v1/suggestions?dataset_id=default&limit=5
+func (s *APIServer) handleSuggestions(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("dataset_id")
+	if id == "" {
+		id = DefaultDatasetID
+	}
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": T("error.invalid_limit")})
+			return
+		}
+		limit = n
+	}
+	table, err := s.QA.Registry.Table(principalOrLocal(r.Context()), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"suggestions": SuggestQuestions(table, limit)})
+}
+
+// FeedbackRequest struct body untuk POST /v1/feedback
+type FeedbackRequest struct {
+	ID       string `json:"id"`
+	Correct  bool   `json:"correct"`
+	Expected string `json:"expected,omitempty"`
+}
+
+func (s *APIServer) handleFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, T("error.method_not_allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+	var req FeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": T("error.invalid_json", err)})
+		return
+	}
+	principal, _ := PrincipalFromContext(r.Context())
+	ex, err := s.QA.Pipeline.RecordFeedback(principal.Tenant, req.ID, req.Correct, req.Expected)
+	switch {
+	case errors.Is(err, ErrEntryNotFound), errors.Is(err, ErrNoHistory):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		writeJSON(w, http.StatusOK, ex)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError memakai pemetaan status gRPC yang sama lalu mengubahnya ke status HTTP
+func writeError(w http.ResponseWriter, err error) {
+	// Dispatcher penuh: klien diminta mencoba lagi, bukan menganggap pertanyaannya salah
+	if errors.Is(err, ErrOverloaded) {
+		w.Header().Set("Retry-After", "1")
+	}
+	st := status.Convert(grpcStatusFromError(err))
+	writeJSON(w, httpStatusFromCode(st.Code()), map[string]string{"error": st.Message()})
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// ErrUnauthenticated dikembalikan jika kredensial tidak ada atau tidak valid
+var ErrUnauthenticated = messageError("error.unauthenticated")
+
+// Principal struct identitas user yang sudah terautentikasi
+type Principal struct {
+	UserID string `json:"user"`
+	Tenant string `json:"tenant"`
+}
+
+// LocalPrincipal dipakai saat server berjalan tanpa autentikasi (mode satu rumah)
+var LocalPrincipal = Principal{UserID: "local", Tenant: "local"}
+
+type principalKey struct{}
+
+// WithPrincipal menyimpan principal ke context
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext mengambil principal dari context
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Authenticator interface untuk memverifikasi kredensial menjadi Principal
+type Authenticator interface {
+	Authenticate(credential string) (Principal, error)
+}
+
+// APIKeyAuthenticator struct autentikasi dengan API key statis
+type APIKeyAuthenticator struct {
+	// key disimpan sebagai hash sha256 agar tidak tersimpan mentah di memori
+	keys map[[sha256.Size]byte]Principal
+	// tenants tenant dari entry pertama setiap user, lihat TenantOf
+	tenants map[string]string
+}
+
+// APIKeyEntry struct satu baris di file API key
+type APIKeyEntry struct {
+	Key    string `json:"key"`
+	UserID string `json:"user"`
+	Tenant string `json:"tenant"`
+}
+
+// NewAPIKeyAuthenticator membuat authenticator dari daftar API key
+func NewAPIKeyAuthenticator(entries []APIKeyEntry) *APIKeyAuthenticator {
+	a := &APIKeyAuthenticator{keys: make(map[[sha256.Size]byte]Principal), tenants: make(map[string]string)}
+	for _, e := range entries {
+		tenant := e.Tenant
+		if tenant == "" {
+			tenant = e.UserID
+		}
+		a.keys[sha256.Sum256([]byte(e.Key))] = Principal{UserID: e.UserID, Tenant: tenant}
+		if _, ok := a.tenants[e.UserID]; !ok {
+			a.tenants[e.UserID] = tenant
+		}
+	}
+	return a
+}
+
+// TenantOf mengembalikan tenant user di file API key; jika user punya beberapa key, tenant entry
+// pertama yang dipakai
+func (a *APIKeyAuthenticator) TenantOf(user string) (string, bool) {
+	tenant, ok := a.tenants[user]
+	return tenant, ok
+}
+
+// LoadAPIKeys membaca file JSON berisi array APIKeyEntry
+func LoadAPIKeys(path string) (*APIKeyAuthenticator, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []APIKeyEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", T("auth.parse_keys", path), err)
+	}
+	return NewAPIKeyAuthenticator(entries), nil
+}
+
+// Authenticate mencari principal untuk API key
+func (a *APIKeyAuthenticator) Authenticate(credential string) (Principal, error) {
+	p, ok := a.keys[sha256.Sum256([]byte(credential))]
+	if !ok {
+		return Principal{}, ErrUnauthenticated
+	}
+	return p, nil
+}
+
+// JWTAuthenticator struct autentikasi JWT HS256 dengan claim "sub" dan "tenant"
+type JWTAuthenticator struct {
+	Secret []byte
+	Now    func() time.Time
+}
+
+type jwtClaims struct {
+	Subject string `json:"sub"`
+	Tenant  string `json:"tenant"`
+	Expiry  int64  `json:"exp"`
+}
+
+// Authenticate memverifikasi signature dan masa berlaku token
+func (a *JWTAuthenticator) Authenticate(credential string) (Principal, error) {
+	parts := strings.Split(credential, ".")
+	if len(parts) != 3 {
+		return Principal{}, ErrUnauthenticated
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Principal{}, ErrUnauthenticated
+	}
+
+	mac := hmac.New(sha256.New, a.Secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return Principal{}, ErrUnauthenticated
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil || claims.Subject == "" {
+		return Principal{}, ErrUnauthenticated
+	}
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	if claims.Expiry != 0 && now().Unix() >= claims.Expiry {
+		return Principal{}, fmt.Errorf("%w: %s", ErrUnauthenticated, T("auth.token_expired"))
+	}
+
+	tenant := claims.Tenant
+	if tenant == "" {
+		tenant = claims.Subject
+	}
+	return Principal{UserID: claims.Subject, Tenant: tenant}, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// MultiAuthenticator struct yang memilih JWT atau API key sesuai bentuk kredensial
+type MultiAuthenticator struct {
+	APIKeys *APIKeyAuthenticator
+	JWT     *JWTAuthenticator
+}
+
+// Authenticate mencoba JWT untuk token bertitik dua, selain itu API key
+func (m *MultiAuthenticator) Authenticate(credential string) (Principal, error) {
+	if credential == "" {
+		return Principal{}, ErrUnauthenticated
+	}
+	if m.JWT != nil && strings.Count(credential, ".") == 2 {
+		return m.JWT.Authenticate(credential)
+	}
+	if m.APIKeys != nil {
+		return m.APIKeys.Authenticate(credential)
+	}
+	return Principal{}, ErrUnauthenticated
+}
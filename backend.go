@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultModel model TAPAS yang dipakai secara default
+const DefaultModel = "google/tapas-base-finetuned-wtq"
+
+// DefaultModelURL endpoint Huggingface untuk model TAPAS yang dipakai secara default
+const DefaultModelURL = "https://api-inference.huggingface.co/models/" + DefaultModel
+
+// SQAModel varian TAPAS untuk pertanyaan berurutan yang menerima koordinat jawaban sebelumnya
+const SQAModel = "google/tapas-base-finetuned-sqa"
+
+// ModelURL mengembalikan endpoint Huggingface Inference API untuk sebuah model
+func ModelURL(model string) string {
+	return "https://api-inference.huggingface.co/models/" + model
+}
+
+// ErrMaxRetries dikembalikan jika model tetap loading setelah semua percobaan habis
+var ErrMaxRetries = messageError("error.max_retries")
+
+// ModelError struct untuk menyimpan response gagal dari AI model beserta status HTTP-nya
+type ModelError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *ModelError) Error() string {
+	return T("error.model_status", e.Status, e.Body)
+}
+
+// ProgressFunc dipanggil setiap kali model masih loading dan request akan diulang
+type ProgressFunc func(attempt int, wait time.Duration)
+
+type progressKey struct{}
+
+// WithProgress menyisipkan ProgressFunc ke context agar pemanggil bisa memantau loading model
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ProgressFromContext mengambil ProgressFunc dari context, nil jika tidak ada
+func ProgressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+// Backend interface untuk model table-QA yang bisa dipakai oleh pipeline
+type Backend interface {
+	Name() string
+	Ask(ctx context.Context, payload Inputs) (Response, error)
+}
+
+// HFBackend struct untuk membungkus AIModelConnector sebagai Backend
+type HFBackend struct {
+	Connector *AIModelConnector
+	Token     string
+	// Model nama model Huggingface, kosong berarti memakai URL connector
+	Model string
+}
+
+// Name mengembalikan nama backend
+func (b *HFBackend) Name() string {
+	switch {
+	case b.Model != "":
+		return "hf:" + b.Model
+	case b.Connector.URL != "":
+		return "hf:" + b.Connector.URL
+	}
+	return "hf:" + DefaultModel
+}
+
+// Ask mengirim payload ke Huggingface Inference API
+func (b *HFBackend) Ask(ctx context.Context, payload Inputs) (Response, error) {
+	if b.Model == "" {
+		return b.Connector.ConnectAIModelContext(ctx, payload, b.Token)
+	}
+	connector := *b.Connector
+	connector.URL = ModelURL(b.Model)
+	return connector.ConnectAIModelContext(ctx, payload, b.Token)
+}
+
+// BuildBackend membangun backend dari konfigurasi model dengan cara yang sama untuk REPL, server,
+// batch, dan eval: Huggingface (model SQA jika diminta) atau fake server, model ONNX lokal, model
+// cadangan, lalu mode privasi di paling luar. Setiap model dibungkus dispatcher. closeFn menutup
+// fake server dan sesi ONNX, dan wajib dipanggil setelah backend tidak dipakai.
+func BuildBackend(cfg Config, dispatcher *Dispatcher) (backend Backend, closeFn func(), err error) {
+	var closers []func()
+	closeFn = func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			closeFn()
+		}
+	}()
+
+	connector := &AIModelConnector{Client: &http.Client{}, URL: cfg.Model.URL, MaxRetries: cfg.Model.MaxRetries}
+	// Profil dev menjawab dengan fake server lokal sehingga bisa dicoba tanpa token
+	if cfg.Model.Fake {
+		url, closeFake, err := StartFakeModel()
+		if err != nil {
+			return nil, closeFn, err
+		}
+		closers = append(closers, closeFake)
+		connector.URL = url
+	}
+	token := cfg.Model.Token
+	backend = dispatcher.Wrap(&HFBackend{Connector: connector, Token: token})
+	if cfg.Model.SQA {
+		backend = dispatcher.Wrap(&HFBackend{Connector: connector, Token: token, Model: SQAModel})
+	}
+	// Model ONNX lokal menggantikan Huggingface sehingga tabel tidak keluar dari mesin ini
+	if cfg.Model.ONNX != "" {
+		local, err := NewONNXBackend(cfg.Model.ONNX, cfg.Model.ONNXLibrary)
+		if err != nil {
+			return nil, closeFn, err
+		}
+		closers = append(closers, func() { local.Close() })
+		local.MaxTokens = cfg.MaxTokens
+		backend = dispatcher.Wrap(local)
+	}
+	// Model cadangan dicoba jika confidence jawaban model utama di bawah min_confidence
+	if cfg.Model.FallbackModels != "" {
+		// Model cadangan selalu endpoint Huggingface, jadi tabel pertanyaan yang kurang yakin keluar dari mesin ini
+		if cfg.Model.ONNX != "" {
+			log.Println(T("backend.fallback_remote", cfg.Model.FallbackModels))
+		}
+		router := &RouterBackend{Backends: []Backend{backend}, MinConfidence: cfg.Model.MinConfidence}
+		for _, model := range strings.Split(cfg.Model.FallbackModels, ",") {
+			router.Backends = append(router.Backends, dispatcher.Wrap(&HFBackend{Connector: connector, Token: token, Model: strings.TrimSpace(model)}))
+		}
+		backend = router
+	}
+	// Mode privasi membungkus backend paling luar sehingga semua model hanya melihat data samaran
+	if cfg.Privacy.Columns != "" {
+		columns, err := ParsePrivacy(cfg.Privacy.Columns)
+		if err != nil {
+			return nil, closeFn, err
+		}
+		shift := cfg.Privacy.DateShift
+		if shift == 0 {
+			shift = 30 + rand.New(rand.NewSource(time.Now().UnixNano())).Intn(336)
+		}
+		backend = &PrivateBackend{Backend: backend, Columns: columns, DateShift: shift}
+	}
+	return backend, closeFn, nil
+}
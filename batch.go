@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strings"
+)
+
+// BatchResult struct hasil satu pertanyaan di mode batch
+type BatchResult struct {
+	Question string   `json:"question"`
+	Answer   string   `json:"answer,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+	Filters  []string `json:"filters,omitempty"`
+	RowsSent int      `json:"rows_sent"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// RunBatch menjawab setiap baris pertanyaan dari in dan menulis hasilnya sebagai JSON Lines ke out.
+// Baris kosong dan baris yang diawali '#' dilewati; error satu pertanyaan tidak menghentikan batch.
+// workers pertanyaan dijawab bersamaan dengan PriorityBatch, tetapi hasilnya tetap ditulis sesuai
+// urutan pertanyaan.
+func RunBatch(ctx context.Context, pipeline *Pipeline, table map[string][]string, filter string, workers int, in io.Reader, out io.Writer) error {
+	// Filter diperiksa sekali di awal agar filter yang salah tidak menghasilkan error di setiap baris
+	if strings.TrimSpace(filter) != "" {
+		if _, err := ParseFilter(filter, InferSchema(table)); err != nil {
+			return err
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	askCtx := WithPriority(ctx, PriorityBatch)
+	// stop menghentikan pembacaan pertanyaan baru jika penulisan hasil gagal
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// pending berisi hasil per pertanyaan sesuai urutan, slots membatasi jumlah worker
+	pending := make(chan chan BatchResult, workers)
+	slots := make(chan struct{}, workers)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(pending)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			query := strings.TrimSpace(scanner.Text())
+			if query == "" || strings.HasPrefix(query, "#") {
+				continue
+			}
+			// Saat shutdown batch berhenti; hasil yang sudah ditulis tetap utuh
+			if err := ctx.Err(); err != nil {
+				errc <- err
+				return
+			}
+			done := make(chan BatchResult, 1)
+			select {
+			case slots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			select {
+			case pending <- done:
+			case <-stop:
+				return
+			}
+			go func() {
+				done <- batchAnswer(askCtx, pipeline, table, filter, query)
+				<-slots
+			}()
+		}
+		errc <- scanner.Err()
+	}()
+
+	enc := json.NewEncoder(out)
+	for done := range pending {
+		if err := enc.Encode(<-done); err != nil {
+			return err
+		}
+	}
+	return <-errc
+}
+
+// batchAnswer menjawab satu pertanyaan batch
+func batchAnswer(ctx context.Context, pipeline *Pipeline, table map[string][]string, filter, query string) BatchResult {
+	result, err := pipeline.Ask(ctx, Question{DatasetID: DefaultDatasetID, Table: table, Query: query, Filter: filter})
+	line := BatchResult{Question: query, Filters: result.Filters, RowsSent: result.RowsSent}
+	if err != nil {
+		line.Error = err.Error()
+	} else {
+		line.Answer, line.Unit = result.Answer, result.Unit
+	}
+	return line
+}
+
+// runBatch menjalankan perintah "batch": go run . batch -questions questions.txt -filter 'Room = "Kitchen"'
+func runBatch(ctx context.Context, args []string, cfg Config) error {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	questionsFile := fs.String("questions", "", "file with one question per line (default stdin)")
+	csvFile := fs.String("csv", cfg.CSV, "table to ask the questions against (default: the profile's csv)")
+	filter := fs.String("filter", "", "filter applied to the table before every question, e.g. 'Room = \"Kitchen\" and Energy_Consumption > 1'")
+	model := fs.String("model", "", "Huggingface model to ask instead of the configured backend")
+	outFile := fs.String("out", "", "write JSON Lines results to this file (default stdout)")
+	workers := fs.Int("workers", 1, "questions answered at the same time; results keep the question order")
+	fs.Parse(args)
+
+	data, err := ReadDataFile(*csvFile)
+	if err != nil {
+		return err
+	}
+	table, err := CsvToSlice(string(data))
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if *questionsFile != "" {
+		f, err := os.Open(*questionsFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	out := io.Writer(os.Stdout)
+	if *outFile != "" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = newDataLineWriter(f)
+	}
+
+	// Backend dibangun sama seperti REPL dan server; dispatcher menerapkan batas per backend dan
+	// antrean yang sama, dengan prioritas batch
+	if *model != "" {
+		cfg.Model.URL = ModelURL(*model)
+	}
+	dispatcher, err := NewDispatcherFromConfig(cfg.Server)
+	if err != nil {
+		return err
+	}
+	backend, closeBackend, err := BuildBackend(cfg, dispatcher)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+	pipeline := &Pipeline{Backend: backend, Normalize: cfg.Normalize, PreAggregate: cfg.PreAggregate, MaxTokens: cfg.MaxTokens}
+	return RunBatch(ctx, pipeline, table, *filter, *workers, in, out)
+}
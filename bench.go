@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	benchAppliances = []string{"Refrigerator", "Air Conditioner", "Washing Machine", "Television", "Lights", "Microwave", "Heater"}
+	benchRooms      = []string{"Kitchen", "Living Room", "Bedroom", "Laundry Room", "Bathroom"}
+)
+
+// GenerateCSV membuat CSV sintetis dengan kolom yang sama seperti data-series.csv, satu baris per jam
+func GenerateCSV(rows int, seed int64) string {
+	rng := rand.New(rand.NewSource(seed))
+	start := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var sb strings.Builder
+	sb.Grow(rows * 56)
+	sb.WriteString("Date,Time,Appliance,Energy_Consumption,Room,Status\n")
+	for i := 0; i < rows; i++ {
+		t := start.Add(time.Duration(i) * time.Hour)
+		status := "On"
+		if rng.Intn(4) == 0 {
+			status = "Off"
+		}
+		fmt.Fprintf(&sb, "%s,%s,%s,%.1f,%s,%s\n",
+			t.Format(dateLayout), t.Format("15:04"),
+			benchAppliances[rng.Intn(len(benchAppliances))],
+			rng.Float64()*3,
+			benchRooms[rng.Intn(len(benchRooms))],
+			status)
+	}
+	return sb.String()
+}
+
+// Bench struct satu benchmark: Op dijalankan berulang oleh MeasureBench atau testing.B
+type Bench struct {
+	// Bytes jumlah byte yang diproses per Op untuk menghitung MB/s, 0 jika tidak relevan
+	Bytes int64
+	Op    func() error
+	// Extra metrik tambahan yang tidak bergantung pada jumlah iterasi, misalnya perkiraan memori tabel
+	Extra map[string]float64
+	// Close membersihkan resource milik benchmark, boleh nil
+	Close func()
+}
+
+// failedBench benchmark yang persiapannya gagal; Op langsung mengembalikan err
+func failedBench(err error) Bench {
+	return Bench{Op: func() error { return err }}
+}
+
+// BenchCsvToSlice benchmark throughput CsvToSlice untuk satu CSV
+func BenchCsvToSlice(data string) Bench {
+	return Bench{Bytes: int64(len(data)), Op: func() error {
+		_, err := CsvToSlice(data)
+		return err
+	}}
+}
+
+// BenchMarshalInputs benchmark json.Marshal untuk payload Inputs
+func BenchMarshalInputs(table map[string][]string) Bench {
+	payload := Inputs{Table: table, Query: "What is the average energy consumption per month?"}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return failedBench(err)
+	}
+	return Bench{Bytes: int64(len(data)), Op: func() error {
+		_, err := json.Marshal(payload)
+		return err
+	}}
+}
+
+// BenchColumnarBuild benchmark mengubah tabel string menjadi ColumnarTable, sekaligus
+// melaporkan perkiraan memori kedua representasi (string-B dan columnar-B)
+func BenchColumnarBuild(table map[string][]string) Bench {
+	columnar, err := NewColumnarTable(table)
+	if err != nil {
+		return failedBench(err)
+	}
+	return Bench{
+		Op: func() error {
+			_, err := NewColumnarTable(table)
+			return err
+		},
+		Extra: map[string]float64{
+			"string-B":   float64(tableSizeBytes(table)),
+			"columnar-B": float64(columnar.SizeBytes()),
+		},
+	}
+}
+
+// BenchColumnarTable benchmark membuat ulang tabel string dari ColumnarTable untuk Inputs
+func BenchColumnarTable(table map[string][]string) Bench {
+	columnar, err := NewColumnarTable(table)
+	if err != nil {
+		return failedBench(err)
+	}
+	return Bench{Op: func() error {
+		columnar.Table()
+		return nil
+	}}
+}
+
+// BenchSumStrings benchmark menjumlahkan Energy_Consumption dengan strconv di setiap cell
+func BenchSumStrings(table map[string][]string) Bench {
+	return Bench{Op: func() error {
+		sumStrings(table, "Energy_Consumption")
+		return nil
+	}}
+}
+
+// BenchSumColumnar benchmark penjumlahan yang sama di FloatVector
+func BenchSumColumnar(table map[string][]string) Bench {
+	columnar, err := NewColumnarTable(table)
+	if err != nil {
+		return failedBench(err)
+	}
+	return Bench{Op: func() error {
+		_, _, err := columnar.Sum("Energy_Consumption")
+		return err
+	}}
+}
+
+// BenchAskEndToEnd benchmark latency satu pertanyaan lewat Pipeline ke fake server lokal
+func BenchAskEndToEnd(table map[string][]string) Bench {
+	url, closeServer, err := StartFakeModel()
+	if err != nil {
+		return failedBench(err)
+	}
+	connector := &AIModelConnector{Client: &http.Client{}, URL: url}
+	pipeline := &Pipeline{Backend: &HFBackend{Connector: connector, Token: "bench"}}
+	question := Question{Table: table, Query: "How much power was consumed in June 2023?"}
+	return Bench{
+		Op: func() error {
+			_, err := pipeline.Ask(context.Background(), question)
+			return err
+		},
+		Close: closeServer,
+	}
+}
+
+// BenchResult struct satu hasil benchmark dalam file JSON
+type BenchResult struct {
+	Name        string  `json:"name"`
+	Rows        int     `json:"rows"`
+	N           int     `json:"n"`
+	NsPerOp     int64   `json:"ns_per_op"`
+	MBPerSec    float64 `json:"mb_per_s,omitempty"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	// Extra metrik tambahan dari Bench.Extra, misalnya perkiraan memori tabel
+	Extra map[string]float64 `json:"extra,omitempty"`
+}
+
+// BenchReport struct isi file hasil benchmark, dipakai untuk membandingkan antar versi
+type BenchReport struct {
+	Version   string        `json:"version"`
+	GoVersion string        `json:"go_version"`
+	GOOS      string        `json:"goos"`
+	GOARCH    string        `json:"goarch"`
+	Time      time.Time     `json:"time"`
+	Results   []BenchResult `json:"results"`
+}
+
+// MeasureBench menjalankan bench.Op berulang sampai total waktunya minimal d, dengan cara yang sama
+// seperti testing.Benchmark: jumlah iterasi diperbesar dari 1 dan alokasi dihitung dari runtime.MemStats
+func MeasureBench(name string, rows int, bench Bench, d time.Duration) (BenchResult, error) {
+	if bench.Close != nil {
+		defer bench.Close()
+	}
+	n := 1
+	for {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			if err := bench.Op(); err != nil {
+				return BenchResult{}, fmt.Errorf("%s rows=%d: %w", name, rows, err)
+			}
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if elapsed < d && n < 1e9 {
+			// Perkirakan iterasi yang dibutuhkan dengan sedikit kelebihan, paling banyak 100 kali lipat
+			next := n * 100
+			if elapsed > 0 {
+				if predicted := int(int64(n) * int64(d) * 6 / 5 / int64(elapsed)); predicted < next {
+					next = predicted
+				}
+			}
+			if next <= n {
+				next = n + 1
+			}
+			n = next
+			continue
+		}
+
+		res := BenchResult{
+			Name:        name,
+			Rows:        rows,
+			N:           n,
+			NsPerOp:     elapsed.Nanoseconds() / int64(n),
+			AllocsPerOp: int64(after.Mallocs-before.Mallocs) / int64(n),
+			BytesPerOp:  int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+			Extra:       bench.Extra,
+		}
+		if bench.Bytes > 0 && elapsed > 0 {
+			res.MBPerSec = float64(bench.Bytes) * float64(n) / 1e6 / elapsed.Seconds()
+		}
+		return res, nil
+	}
+}
+
+// runBench menjalankan perintah "bench": go run . bench -rows 1000,100000 -out bench.json
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	rowsList := fs.String("rows", "1000,10000,100000", "comma-separated table sizes to generate")
+	outFile := fs.String("out", "bench.json", "write results as JSON to this file (empty = stdout only)")
+	version := fs.String("version", "dev", "label stored with the results, e.g. a git tag")
+	benchTime := fs.Duration("benchtime", time.Second, "minimum run time of each benchmark")
+	fs.Parse(args)
+
+	report := BenchReport{
+		Version:   *version,
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		Time:      time.Now().UTC(),
+	}
+
+	for _, field := range strings.Split(*rowsList, ",") {
+		rows, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || rows <= 0 {
+			return errors.New(T("bench.invalid_rows", field))
+		}
+		data := GenerateCSV(rows, 1)
+		table, err := CsvToSlice(data)
+		if err != nil {
+			return err
+		}
+
+		benches := []struct {
+			name  string
+			bench Bench
+		}{
+			{"CsvToSlice", BenchCsvToSlice(data)},
+			{"MarshalInputs", BenchMarshalInputs(table)},
+			{"ColumnarBuild", BenchColumnarBuild(table)},
+			{"ColumnarTable", BenchColumnarTable(table)},
+			{"SumStrings", BenchSumStrings(table)},
+			{"SumColumnar", BenchSumColumnar(table)},
+			{"AskEndToEnd", BenchAskEndToEnd(table)},
+		}
+		for _, bench := range benches {
+			res, err := MeasureBench(bench.name, rows, bench.bench, *benchTime)
+			if err != nil {
+				return err
+			}
+			report.Results = append(report.Results, res)
+			fmt.Printf("%-14s rows=%-8d %12d ns/op %10.2f MB/s %8d allocs/op\n", res.Name, rows, res.NsPerOp, res.MBPerSec, res.AllocsPerOp)
+		}
+	}
+
+	if *outFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(*outFile, data, 0o644)
+}
@@ -0,0 +1,70 @@
+package main_test
+
+import (
+	"fmt"
+	"testing"
+
+	main "a21hc3NpZ25tZW50"
+)
+
+var benchSizes = []int{1000, 10000, 100000}
+
+// run menjalankan main.Bench sebagai sub-benchmark; fungsi b.Run dipanggil beberapa kali dengan
+// b.N yang makin besar, jadi Close baru dipanggil setelah semuanya selesai
+func run(b *testing.B, name string, bench main.Bench) {
+	if bench.Close != nil {
+		defer bench.Close()
+	}
+	b.Run(name, func(b *testing.B) {
+		b.SetBytes(bench.Bytes)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := bench.Op(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		for unit, value := range bench.Extra {
+			b.ReportMetric(value, unit)
+		}
+	})
+}
+
+func BenchmarkCsvToSlice(b *testing.B) {
+	for _, rows := range benchSizes {
+		run(b, fmt.Sprintf("rows=%d", rows), main.BenchCsvToSlice(main.GenerateCSV(rows, 1)))
+	}
+}
+
+func BenchmarkMarshalInputs(b *testing.B) {
+	for _, rows := range benchSizes {
+		table, err := main.CsvToSlice(main.GenerateCSV(rows, 1))
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, fmt.Sprintf("rows=%d", rows), main.BenchMarshalInputs(table))
+	}
+}
+
+func BenchmarkAskEndToEnd(b *testing.B) {
+	for _, rows := range []int{100, 1000} {
+		table, err := main.CsvToSlice(main.GenerateCSV(rows, 1))
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, fmt.Sprintf("rows=%d", rows), main.BenchAskEndToEnd(table))
+	}
+}
+
+func BenchmarkColumnar(b *testing.B) {
+	for _, rows := range benchSizes {
+		table, err := main.CsvToSlice(main.GenerateCSV(rows, 1))
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, fmt.Sprintf("build/rows=%d", rows), main.BenchColumnarBuild(table))
+		run(b, fmt.Sprintf("table/rows=%d", rows), main.BenchColumnarTable(table))
+		run(b, fmt.Sprintf("sum-strings/rows=%d", rows), main.BenchSumStrings(table))
+		run(b, fmt.Sprintf("sum-columnar/rows=%d", rows), main.BenchSumColumnar(table))
+	}
+}
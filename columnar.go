@@ -0,0 +1,484 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Representasi kolom bertipe untuk tabel besar. Angka disimpan sebagai int64/float64, tanggal dan jam
+// sebagai Unix detik, dan teks dengan dictionary encoding sehingga nilai berulang seperti nama ruangan
+// hanya disimpan sekali. Cell kosong ditandai di null bitmap. Tabel string untuk Inputs tetap bisa
+// dibuat ulang dengan Table(), dan hasilnya sama persis dengan tabel asal.
+
+// ColumnType tipe penyimpanan satu kolom
+type ColumnType int
+
+const (
+	ColumnString ColumnType = iota
+	ColumnInt
+	ColumnFloat
+	ColumnTime
+)
+
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnInt:
+		return "int"
+	case ColumnFloat:
+		return "float"
+	case ColumnTime:
+		return "time"
+	}
+	return "string"
+}
+
+// timeLayouts format tanggal dan jam yang dikenali untuk ColumnTime, dicoba berurutan
+var timeLayouts = []string{dateLayout, "15:04", "15:04:05", "2006-01-02 15:04:05", time.RFC3339}
+
+// Bitmap satu bit per baris; bit yang menyala berarti cell kosong (null)
+type Bitmap []uint64
+
+func newBitmap(n int) Bitmap { return make(Bitmap, (n+63)/64) }
+
+func (b Bitmap) set(i int) { b[i/64] |= 1 << uint(i%64) }
+
+// Get mengecek bit ke-i
+func (b Bitmap) Get(i int) bool { return b[i/64]&(1<<uint(i%64)) != 0 }
+
+// Vector satu kolom bertipe
+type Vector interface {
+	Type() ColumnType
+	Len() int
+	IsNull(i int) bool
+	// String isi cell seperti di CSV, "" untuk null
+	String(i int) string
+	// Value isi cell sebagai nilai ekspresi
+	Value(i int) Value
+}
+
+// IntVector kolom bilangan bulat
+type IntVector struct {
+	values []int64
+	nulls  Bitmap
+}
+
+func (v *IntVector) Type() ColumnType  { return ColumnInt }
+func (v *IntVector) Len() int          { return len(v.values) }
+func (v *IntVector) IsNull(i int) bool { return v.nulls.Get(i) }
+func (v *IntVector) Int(i int) int64   { return v.values[i] }
+func (v *IntVector) Value(i int) Value { return nullOr(v, i, numberValue(float64(v.values[i]))) }
+func (v *IntVector) String(i int) string {
+	return nullOrString(v, i, strconv.FormatInt(v.values[i], 10))
+}
+
+// FloatVector kolom bilangan desimal. precision jumlah angka di belakang koma jika seluruh kolom
+// ditulis dengan jumlah yang sama (misalnya "1.0", "2.5"), -1 berarti format terpendek.
+type FloatVector struct {
+	values    []float64
+	precision int
+	nulls     Bitmap
+}
+
+func (v *FloatVector) Type() ColumnType    { return ColumnFloat }
+func (v *FloatVector) Len() int            { return len(v.values) }
+func (v *FloatVector) IsNull(i int) bool   { return v.nulls.Get(i) }
+func (v *FloatVector) Float(i int) float64 { return v.values[i] }
+func (v *FloatVector) Value(i int) Value   { return nullOr(v, i, numberValue(v.values[i])) }
+func (v *FloatVector) String(i int) string {
+	return nullOrString(v, i, strconv.FormatFloat(v.values[i], 'f', v.precision, 64))
+}
+
+// TimeVector kolom tanggal atau jam dengan satu format untuk seluruh kolom
+type TimeVector struct {
+	layout string
+	values []int64
+	nulls  Bitmap
+}
+
+func (v *TimeVector) Type() ColumnType  { return ColumnTime }
+func (v *TimeVector) Len() int          { return len(v.values) }
+func (v *TimeVector) IsNull(i int) bool { return v.nulls.Get(i) }
+func (v *TimeVector) Layout() string    { return v.layout }
+func (v *TimeVector) Time(i int) time.Time {
+	return time.Unix(v.values[i], 0).UTC()
+}
+func (v *TimeVector) String(i int) string {
+	return nullOrString(v, i, v.Time(i).Format(v.layout))
+}
+func (v *TimeVector) Value(i int) Value {
+	if v.IsNull(i) {
+		return Value{}
+	}
+	// Hanya tanggal murni yang menjadi KindDate, jam tetap string seperti di cellValue
+	if v.layout == dateLayout {
+		return Value{Kind: KindDate, Str: v.String(i)}
+	}
+	return Value{Kind: KindString, Str: v.String(i)}
+}
+
+// StringVector kolom teks dengan dictionary encoding
+type StringVector struct {
+	dict  []string
+	codes []uint32
+	nulls Bitmap
+}
+
+func (v *StringVector) Type() ColumnType  { return ColumnString }
+func (v *StringVector) Len() int          { return len(v.codes) }
+func (v *StringVector) IsNull(i int) bool { return v.nulls.Get(i) }
+
+// Dictionary nilai unik di kolom sesuai urutan kemunculan
+func (v *StringVector) Dictionary() []string { return v.dict }
+
+// String tidak memakai nullOrString karena dict bisa kosong jika semua cell null
+func (v *StringVector) String(i int) string {
+	if v.IsNull(i) {
+		return ""
+	}
+	return v.dict[v.codes[i]]
+}
+
+func (v *StringVector) Value(i int) Value {
+	if v.IsNull(i) {
+		return Value{}
+	}
+	return Value{Kind: KindString, Str: v.dict[v.codes[i]]}
+}
+
+func nullOr(v Vector, i int, value Value) Value {
+	if v.IsNull(i) {
+		return Value{}
+	}
+	return value
+}
+
+func nullOrString(v Vector, i int, s string) string {
+	if v.IsNull(i) {
+		return ""
+	}
+	return s
+}
+
+// NewVector memilih tipe paling ringkas yang bisa menghasilkan ulang setiap cell persis sama:
+// int, lalu float, lalu tanggal/jam, selain itu string. Kolom berisi "1.20" dan "0.5" tetap string
+// karena jumlah angka di belakang komanya tidak seragam dan tidak bisa dicetak ulang persis sama.
+func NewVector(cells []string) Vector {
+	if v, ok := intVector(cells); ok {
+		return v
+	}
+	if v, ok := floatVector(cells, decimalPlaces(cells)); ok {
+		return v
+	}
+	if v, ok := floatVector(cells, -1); ok {
+		return v
+	}
+	for _, layout := range timeLayouts {
+		if v, ok := timeVector(cells, layout); ok {
+			return v
+		}
+	}
+	return stringVector(cells)
+}
+
+// hasValue true jika ada minimal satu cell terisi; kolom yang semuanya kosong disimpan sebagai string
+func hasValue(cells []string) bool {
+	for _, c := range cells {
+		if c != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func intVector(cells []string) (*IntVector, bool) {
+	if !hasValue(cells) {
+		return nil, false
+	}
+	v := &IntVector{values: make([]int64, len(cells)), nulls: newBitmap(len(cells))}
+	for i, c := range cells {
+		if c == "" {
+			v.nulls.set(i)
+			continue
+		}
+		n, err := strconv.ParseInt(c, 10, 64)
+		if err != nil || strconv.FormatInt(n, 10) != c {
+			return nil, false
+		}
+		v.values[i] = n
+	}
+	return v, true
+}
+
+// decimalPlaces jumlah angka di belakang koma pada cell pertama yang terisi
+func decimalPlaces(cells []string) int {
+	for _, c := range cells {
+		if c == "" {
+			continue
+		}
+		if dot := strings.IndexByte(c, '.'); dot >= 0 {
+			return len(c) - dot - 1
+		}
+		return 0
+	}
+	return -1
+}
+
+func floatVector(cells []string, precision int) (*FloatVector, bool) {
+	if !hasValue(cells) {
+		return nil, false
+	}
+	v := &FloatVector{values: make([]float64, len(cells)), precision: precision, nulls: newBitmap(len(cells))}
+	for i, c := range cells {
+		if c == "" {
+			v.nulls.set(i)
+			continue
+		}
+		n, err := strconv.ParseFloat(c, 64)
+		if err != nil || strconv.FormatFloat(n, 'f', precision, 64) != c {
+			return nil, false
+		}
+		v.values[i] = n
+	}
+	return v, true
+}
+
+func timeVector(cells []string, layout string) (*TimeVector, bool) {
+	if !hasValue(cells) {
+		return nil, false
+	}
+	v := &TimeVector{layout: layout, values: make([]int64, len(cells)), nulls: newBitmap(len(cells))}
+	for i, c := range cells {
+		if c == "" {
+			v.nulls.set(i)
+			continue
+		}
+		t, err := time.Parse(layout, c)
+		// Zona waktu dan pecahan detik tidak disimpan, jadi cell seperti itu tetap string
+		if err != nil || time.Unix(t.Unix(), 0).UTC().Format(layout) != c {
+			return nil, false
+		}
+		v.values[i] = t.Unix()
+	}
+	return v, true
+}
+
+func stringVector(cells []string) *StringVector {
+	v := &StringVector{codes: make([]uint32, len(cells)), nulls: newBitmap(len(cells))}
+	index := make(map[string]uint32)
+	for i, c := range cells {
+		if c == "" {
+			v.nulls.set(i)
+			continue
+		}
+		code, ok := index[c]
+		if !ok {
+			code = uint32(len(v.dict))
+			index[c] = code
+			v.dict = append(v.dict, c)
+		}
+		v.codes[i] = code
+	}
+	return v
+}
+
+// ColumnarTable struct tabel dalam bentuk kolom bertipe
+type ColumnarTable struct {
+	columns map[string]Vector
+	rows    int
+}
+
+// NewColumnarTable mengubah tabel string menjadi kolom bertipe; semua kolom harus sama panjang
+func NewColumnarTable(table map[string][]string) (*ColumnarTable, error) {
+	t := &ColumnarTable{columns: make(map[string]Vector, len(table)), rows: -1}
+	for _, col := range sortedColumns(table) {
+		cells := table[col]
+		if t.rows >= 0 && len(cells) != t.rows {
+			return nil, errors.New(T("table.ragged", col, len(cells), t.rows))
+		}
+		t.rows = len(cells)
+		t.columns[col] = NewVector(cells)
+	}
+	if t.rows < 0 {
+		t.rows = 0
+	}
+	return t, nil
+}
+
+// CsvToColumnar mem-parse CSV langsung menjadi ColumnarTable
+func CsvToColumnar(data string) (*ColumnarTable, error) {
+	table, err := CsvToSlice(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewColumnarTable(table)
+}
+
+// Rows jumlah baris
+func (t *ColumnarTable) Rows() int { return t.rows }
+
+// Columns nama kolom urut abjad, sama dengan urutan indeks kolom di Response.Coordinates
+func (t *ColumnarTable) Columns() []string {
+	columns := make([]string, 0, len(t.columns))
+	for col := range t.columns {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Column mengembalikan vector satu kolom
+func (t *ColumnarTable) Column(name string) (Vector, bool) {
+	v, ok := t.columns[name]
+	return v, ok
+}
+
+// Table membuat ulang tabel string untuk Inputs
+func (t *ColumnarTable) Table() map[string][]string {
+	table := make(map[string][]string, len(t.columns))
+	for col, v := range t.columns {
+		cells := make([]string, v.Len())
+		for i := range cells {
+			cells[i] = v.String(i)
+		}
+		table[col] = cells
+	}
+	return table
+}
+
+// Schema tipe ekspresi setiap kolom, sama dengan InferSchema pada Table(). Kolom angka dan tanggal
+// tidak perlu dibaca ulang; kolom teks cukup diperiksa per entri dictionary.
+func (t *ColumnarTable) Schema() Schema {
+	schema := make(Schema, len(t.columns))
+	for col, v := range t.columns {
+		switch v := v.(type) {
+		case *IntVector, *FloatVector:
+			schema[col] = KindNumber
+		case *TimeVector:
+			schema[col] = KindString
+			if v.layout == dateLayout {
+				schema[col] = KindDate
+			}
+		case *StringVector:
+			kind := KindNull
+			for _, cell := range v.dict {
+				var done bool
+				if kind, done = mergeKind(kind, cellValue(cell).Kind); done {
+					break
+				}
+			}
+			schema[col] = kind
+		}
+	}
+	return schema
+}
+
+// rowReader membaca baris sebagai Row dengan tipe dari schema, seperti schemaRow. Nilai teks di-parse
+// sekali per entri dictionary sehingga kolom berulang seperti Room tidak di-parse di setiap baris.
+func (t *ColumnarTable) rowReader(schema Schema) func(i int) Row {
+	dicts := make(map[string][]Value)
+	for col, v := range t.columns {
+		if v, ok := v.(*StringVector); ok {
+			values := make([]Value, len(v.dict))
+			for code, cell := range v.dict {
+				values[code] = schemaCell(cell, schema[col])
+			}
+			dicts[col] = values
+		}
+	}
+	return func(i int) Row {
+		return func(col string) Value {
+			v, ok := t.columns[col]
+			if !ok || i >= v.Len() || v.IsNull(i) {
+				return Value{}
+			}
+			if s, ok := v.(*StringVector); ok {
+				return dicts[col][s.codes[i]]
+			}
+			return v.Value(i)
+		}
+	}
+}
+
+// Sum menjumlahkan kolom angka dan mengembalikan jumlah cell yang terisi
+func (t *ColumnarTable) Sum(column string) (float64, int, error) {
+	v, ok := t.columns[column]
+	if !ok {
+		return 0, 0, errors.New(T("table.unknown_column", column))
+	}
+	sum, count := 0.0, 0
+	switch v := v.(type) {
+	case *FloatVector:
+		for i, n := range v.values {
+			if !v.nulls.Get(i) {
+				sum += n
+				count++
+			}
+		}
+	case *IntVector:
+		for i, n := range v.values {
+			if !v.nulls.Get(i) {
+				sum += float64(n)
+				count++
+			}
+		}
+	default:
+		return 0, 0, errors.New(T("table.needs_number", "sum", column, v.Type()))
+	}
+	return sum, count, nil
+}
+
+// sumStrings penjumlahan yang sama di tabel string, pembanding untuk benchmark
+func sumStrings(table map[string][]string, column string) (float64, int) {
+	sum, count := 0.0, 0
+	for _, c := range table[column] {
+		if n, err := strconv.ParseFloat(c, 64); err == nil {
+			sum += n
+			count++
+		}
+	}
+	return sum, count
+}
+
+// Perkiraan ukuran memori, hanya untuk membandingkan kedua representasi di benchmark
+const (
+	stringHeaderBytes = 16
+	sliceHeaderBytes  = 24
+)
+
+// SizeBytes perkiraan memori yang dipakai isi kolom
+func (t *ColumnarTable) SizeBytes() int {
+	size := 0
+	for col, v := range t.columns {
+		size += len(col) + stringHeaderBytes
+		switch v := v.(type) {
+		case *IntVector:
+			size += 8*len(v.values) + 8*len(v.nulls)
+		case *FloatVector:
+			size += 8*len(v.values) + 8*len(v.nulls)
+		case *TimeVector:
+			size += 8*len(v.values) + 8*len(v.nulls) + len(v.layout)
+		case *StringVector:
+			size += 4*len(v.codes) + 8*len(v.nulls)
+			for _, s := range v.dict {
+				size += len(s) + stringHeaderBytes
+			}
+		}
+		size += 3 * sliceHeaderBytes
+	}
+	return size
+}
+
+// tableSizeBytes perkiraan memori tabel string dengan cara hitung yang sama
+func tableSizeBytes(table map[string][]string) int {
+	size := 0
+	for col, cells := range table {
+		size += len(col) + stringHeaderBytes + sliceHeaderBytes
+		for _, c := range cells {
+			size += len(c) + stringHeaderBytes
+		}
+	}
+	return size
+}
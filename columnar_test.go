@@ -0,0 +1,111 @@
+package main_test
+
+import (
+	"io/ioutil"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Columnar table", func() {
+	It("round-trips data-series.csv with typed columns", func() {
+		data, err := ioutil.ReadFile("data-series.csv")
+		Expect(err).ShouldNot(HaveOccurred())
+		table, err := main.CsvToSlice(string(data))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		columnar, err := main.NewColumnarTable(table)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(columnar.Table()).Should(Equal(table))
+		Expect(columnar.Rows()).Should(Equal(len(table["Date"])))
+
+		types := map[string]main.ColumnType{}
+		for _, col := range columnar.Columns() {
+			v, _ := columnar.Column(col)
+			types[col] = v.Type()
+		}
+		Expect(types).Should(Equal(map[string]main.ColumnType{
+			"Date":               main.ColumnTime,
+			"Time":               main.ColumnTime,
+			"Appliance":          main.ColumnString,
+			"Energy_Consumption": main.ColumnFloat,
+			"Room":               main.ColumnString,
+			"Status":             main.ColumnString,
+		}))
+		rooms, _ := columnar.Column("Room")
+		Expect(len(rooms.(*main.StringVector).Dictionary())).Should(BeNumerically("<", columnar.Rows()))
+		Expect(columnar.SizeBytes()).Should(BeNumerically(">", 0))
+		Expect(columnar.Schema()).Should(Equal(main.InferSchema(table)))
+	})
+
+	It("backs filters, grouping and sorting with the same types as the string table", func() {
+		table := map[string][]string{
+			"Date":   {"2023-06-01", "2023-06-02", "2023-06-03", "2023-06-04"},
+			"Room":   {"Kitchen", " Kitchen ", "Garage", ""},
+			"Padded": {"1.20", "0.5", "2", ""},
+			"Code":   {"7", "x", "7", "8"},
+		}
+		columnar, err := main.NewColumnarTable(table)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(columnar.Schema()).Should(Equal(main.InferSchema(table)))
+
+		// Padded tetap StringVector tetapi dibandingkan sebagai angka; Code berisi teks sehingga "7" string
+		filtered, rows, err := main.ApplyFilter(table, `Padded > 1 and Room = "Kitchen" and Code = "7" and Date < 2023-06-03`)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).Should(Equal([]int{0}))
+		Expect(filtered["Room"]).Should(Equal([]string{"Kitchen"}))
+
+		sorted, err := main.SortBy(table, "Padded", true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sorted["Padded"]).Should(Equal([]string{"2", "1.20", "0.5", ""}))
+
+		grouped, err := main.GroupBy(table, []string{"Code"}, main.Aggregation{Func: "sum", Column: "Padded"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(grouped).Should(Equal(map[string][]string{"Code": {"7", "x", "8"}, "Padded_sum": {"3.2", "0.5", ""}}))
+
+		// Tabel yang tidak rata tetap bisa difilter tanpa ColumnarTable
+		_, rows, err = main.ApplyFilter(map[string][]string{"A": {"1", "2"}, "B": {"x", "y", "z"}}, "A > 1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).Should(Equal([]int{1}))
+	})
+
+	It("keeps empty cells as nulls and falls back to strings when a value would not round-trip", func() {
+		table := map[string][]string{
+			"Count":  {"3", "", "12"},
+			"Energy": {"1.5", "", "2"},
+			"Fixed":  {"1.20", "0.50", ""},
+			"Padded": {"1.20", "0.5", ""},
+			"At":     {"2023-06-01T10:00:00+07:00", "", "2023-06-01T03:00:00Z"},
+			"Empty":  {"", "", ""},
+		}
+		columnar, err := main.NewColumnarTable(table)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(columnar.Table()).Should(Equal(table))
+
+		count, _ := columnar.Column("Count")
+		Expect(count.Type()).Should(Equal(main.ColumnInt))
+		Expect(count.IsNull(1)).Should(BeTrue())
+		Expect(count.Value(1).Kind).Should(Equal(main.KindNull))
+		Expect(count.Value(2)).Should(Equal(main.Value{Kind: main.KindNumber, Num: 12}))
+		fixed, _ := columnar.Column("Fixed")
+		Expect(fixed.Type()).Should(Equal(main.ColumnFloat))
+		for _, col := range []string{"Padded", "At", "Empty"} {
+			v, _ := columnar.Column(col)
+			Expect(v.Type()).Should(Equal(main.ColumnString), col)
+		}
+
+		sum, n, err := columnar.Sum("Energy")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sum).Should(Equal(3.5))
+		Expect(n).Should(Equal(2))
+		_, _, err = columnar.Sum("Padded")
+		Expect(err).Should(MatchError(ContainSubstring("needs a number column")))
+	})
+
+	It("rejects columns of different lengths", func() {
+		_, err := main.NewColumnarTable(map[string][]string{"A": {"1"}, "B": {"1", "2"}})
+		Expect(err).Should(MatchError(ContainSubstring("has 2 rows")))
+	})
+})
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// Confidence menghitung skor keyakinan 0..1 dari probabilitas agregator yang dipilih dikali
+// rata-rata probabilitas cell. ok false jika model tidak mengembalikan probabilitas: endpoint
+// table-QA Huggingface tidak pernah mengembalikannya, hanya model ONNX lokal (DecodeTapas).
+func (r Response) Confidence() (float64, bool) {
+	if len(r.CellProbabilities) == 0 && len(r.AggregatorProbabilities) == 0 {
+		return 0, false
+	}
+	score := 1.0
+	if len(r.AggregatorProbabilities) > 0 {
+		aggregator := strings.ToUpper(strings.TrimSpace(r.Aggregator))
+		if aggregator == "" {
+			aggregator = "NONE"
+		}
+		score = r.AggregatorProbabilities[aggregator]
+	}
+	if len(r.CellProbabilities) > 0 {
+		sum := 0.0
+		for _, p := range r.CellProbabilities {
+			sum += p
+		}
+		score *= sum / float64(len(r.CellProbabilities))
+	}
+	return score, true
+}
+
+// RouteAttempt struct satu backend yang dicoba oleh RouterBackend
+type RouteAttempt struct {
+	Backend    string  `json:"backend"`
+	Confidence float64 `json:"confidence,omitempty"`
+	// Scored false jika backend tidak mengembalikan probabilitas
+	Scored   bool   `json:"scored"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RouteFunc dipanggil setiap kali RouterBackend selesai mencoba satu backend
+type RouteFunc func(attempt RouteAttempt)
+
+type routeKey struct{}
+
+// WithRoute menyisipkan RouteFunc ke context agar pemanggil bisa mencatat backend mana yang menjawab
+func WithRoute(ctx context.Context, fn RouteFunc) context.Context {
+	return context.WithValue(ctx, routeKey{}, fn)
+}
+
+func routeFromContext(ctx context.Context) RouteFunc {
+	fn, _ := ctx.Value(routeKey{}).(RouteFunc)
+	return fn
+}
+
+// ErrNoBackend dikembalikan jika RouterBackend tidak punya backend
+var ErrNoBackend = messageError("error.no_backend")
+
+// RouterBackend struct mencoba beberapa backend berurutan dan pindah ke backend berikutnya
+// jika backend gagal atau confidence jawabannya di bawah MinConfidence.
+// Jawaban tanpa probabilitas dianggap cukup yakin karena tidak bisa dinilai, sehingga setelah
+// backend Huggingface router hanya pindah jika backend itu gagal.
+type RouterBackend struct {
+	Backends      []Backend
+	MinConfidence float64
+}
+
+// Name mengembalikan nama semua backend dalam urutan percobaan
+func (r *RouterBackend) Name() string {
+	names := make([]string, len(r.Backends))
+	for i, b := range r.Backends {
+		names[i] = b.Name()
+	}
+	return "router(" + strings.Join(names, ",") + ")"
+}
+
+// Ask mengembalikan jawaban pertama yang cukup yakin. Jika tidak ada, jawaban dengan
+// confidence tertinggi yang dikembalikan; error hanya jika semua backend gagal.
+func (r *RouterBackend) Ask(ctx context.Context, payload Inputs) (Response, error) {
+	route := routeFromContext(ctx)
+	var (
+		best     Response
+		bestConf = -1.0
+		lastErr  error
+	)
+	for _, b := range r.Backends {
+		resp, err := b.Ask(ctx, payload)
+		attempt := RouteAttempt{Backend: b.Name()}
+		if err != nil {
+			attempt.Error = err.Error()
+			if route != nil {
+				route(attempt)
+			}
+			if ctx.Err() != nil {
+				return Response{}, err
+			}
+			lastErr = err
+			continue
+		}
+		attempt.Confidence, attempt.Scored = resp.Confidence()
+		attempt.Accepted = !attempt.Scored || attempt.Confidence >= r.MinConfidence
+		if route != nil {
+			route(attempt)
+		}
+		if attempt.Accepted {
+			return resp, nil
+		}
+		log.Println(T("router.low_confidence", attempt.Backend, attempt.Confidence, r.MinConfidence))
+		if attempt.Confidence > bestConf {
+			best, bestConf = resp, attempt.Confidence
+		}
+	}
+	if bestConf >= 0 {
+		return best, nil
+	}
+	if lastErr == nil {
+		lastErr = ErrNoBackend
+	}
+	return Response{}, lastErr
+}
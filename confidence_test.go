@@ -0,0 +1,92 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Confidence", func() {
+	table := map[string][]string{"Appliance": {"TV", "Fridge"}, "Energy_Consumption": {"0.5", "1.2"}}
+
+	It("combines aggregator and cell probabilities", func() {
+		resp := main.Response{
+			Aggregator:              "SUM",
+			CellProbabilities:       []float64{0.8, 0.6},
+			AggregatorProbabilities: map[string]float64{"NONE": 0.2, "SUM": 0.5},
+		}
+		confidence, ok := resp.Confidence()
+		Expect(ok).Should(BeTrue())
+		Expect(confidence).Should(BeNumerically("~", 0.35, 1e-9))
+
+		_, ok = main.Response{Answer: "1.2"}.Confidence()
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("treats Huggingface answers as unscored because the API returns no probabilities", func() {
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(json.NewDecoder(r.Body).Decode(&body)).Should(Succeed())
+			// Bentuk response table-question-answering Huggingface apa adanya
+			w.Write([]byte(`{"answer":"SUM > 0.5, 1.2","coordinates":[[0,1],[1,1]],"cells":["0.5","1.2"],"aggregator":"SUM"}`))
+		}))
+		defer server.Close()
+
+		connector := &main.AIModelConnector{Client: server.Client(), URL: server.URL}
+		resp, err := connector.ConnectAIModel(main.Inputs{Table: table, Query: "total energy?"}, "token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(body).ShouldNot(HaveKey("parameters"))
+		Expect(resp.Cells).Should(Equal([]string{"0.5", "1.2"}))
+		_, ok := resp.Confidence()
+		Expect(ok).Should(BeFalse())
+	})
+
+	Describe("RouterBackend", func() {
+		low := main.Response{Answer: "0.5", Cells: []string{"0.5"}, Aggregator: "NONE", CellProbabilities: []float64{0.2}}
+		high := main.Response{Answer: "1.2", Cells: []string{"1.2"}, Aggregator: "NONE", CellProbabilities: []float64{0.9}}
+
+		It("falls back when confidence is below the threshold", func() {
+			first := &fakeBackend{name: "small", response: low}
+			second := &fakeBackend{name: "large", response: high}
+			router := &main.RouterBackend{Backends: []main.Backend{first, second}, MinConfidence: 0.5}
+
+			result, err := (&main.Pipeline{Backend: router}).Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy?"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Response.Answer).Should(Equal("1.2"))
+			Expect(result.Route).Should(HaveLen(2))
+			Expect(result.Route[0].Accepted).Should(BeFalse())
+			Expect(result.Route[1].Accepted).Should(BeTrue())
+			Expect(*result.Explanation.Confidence).Should(BeNumerically("~", 0.9))
+		})
+
+		It("keeps the most confident answer when every backend is below the threshold", func() {
+			router := &main.RouterBackend{Backends: []main.Backend{
+				&fakeBackend{response: high},
+				&fakeBackend{err: errors.New("model down")},
+				&fakeBackend{response: low},
+			}, MinConfidence: 0.95}
+			resp, err := router.Ask(context.Background(), main.Inputs{Table: table, Query: "q"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.Answer).Should(Equal("1.2"))
+		})
+
+		It("accepts answers without probabilities and reports errors when all backends fail", func() {
+			router := &main.RouterBackend{Backends: []main.Backend{&fakeBackend{response: main.Response{Answer: "x"}}}, MinConfidence: 0.9}
+			resp, err := router.Ask(context.Background(), main.Inputs{Query: "q"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.Answer).Should(Equal("x"))
+
+			failing := errors.New("model down")
+			router = &main.RouterBackend{Backends: []main.Backend{&fakeBackend{err: failing}}}
+			_, err = router.Ask(context.Background(), main.Inputs{Query: "q"})
+			Expect(err).Should(MatchError(failing))
+		})
+	})
+})
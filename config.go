@@ -0,0 +1,393 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ErrInvalidConfig dikembalikan jika file konfigurasi tidak bisa dibaca atau tidak lolos validasi
+var ErrInvalidConfig = messageError("error.invalid_config")
+
+// DefaultConfigFile file konfigurasi yang dibaca jika -config tidak diisi; boleh tidak ada
+const DefaultConfigFile = "tableqa.yaml"
+
+// DefaultMaxRetries jumlah percobaan AIModelConnector selama model masih loading
+const DefaultMaxRetries = 10
+
+// ModelConfig struct pengaturan model yang ditanya
+type ModelConfig struct {
+	// URL endpoint Huggingface, kosong berarti DefaultModelURL
+	URL   string `yaml:"url"`
+	Token string `yaml:"token"`
+	// Fake menjalankan FakeModelHandler di proses ini sehingga tidak butuh token (profil dev)
+	Fake           bool    `yaml:"fake"`
+	MaxRetries     int     `yaml:"max_retries"`
+	SQA            bool    `yaml:"sqa"`
+	FallbackModels string  `yaml:"fallback_models"`
+	MinConfidence  float64 `yaml:"min_confidence"`
+	ONNX           string  `yaml:"onnx"`
+	ONNXLibrary    string  `yaml:"onnx_library"`
+}
+
+// HistoryConfig struct pengaturan history; Path kosong mematikan history
+type HistoryConfig struct {
+	Path       string `yaml:"path"`
+	MaxAge     string `yaml:"max_age"`
+	MaxEntries int    `yaml:"max_entries"`
+}
+
+// ServerConfig struct pengaturan mode server; GRPC dan HTTP kosong berarti REPL
+type ServerConfig struct {
+	GRPC          string `yaml:"grpc"`
+	HTTP          string `yaml:"http"`
+	APIKeys       string `yaml:"api_keys"`
+	DefaultOwner  string `yaml:"default_owner"`
+	DefaultTenant string `yaml:"default_tenant"`
+	MaxDatasets   int    `yaml:"max_datasets"`
+	MaxRows       int    `yaml:"max_rows"`
+	// QueueSize, Concurrency, dan BackendConcurrency mengatur Dispatcher pemanggilan model
+	QueueSize          int    `yaml:"queue_size"`
+	Concurrency        int    `yaml:"concurrency"`
+	BackendConcurrency string `yaml:"backend_concurrency"`
+}
+
+// PrivacyConfig struct pengaturan mode privasi, lihat ParsePrivacy
+type PrivacyConfig struct {
+	Columns   string `yaml:"columns"`
+	DateShift int    `yaml:"date_shift"`
+}
+
+// Config struct konfigurasi efektif satu profil. Nilainya menjadi default flag, sehingga flag
+// yang diisi langsung di command line tetap menang.
+type Config struct {
+	Profile      string        `yaml:"profile"`
+	CSV          string        `yaml:"csv"`
+	Lang         string        `yaml:"lang"`
+	Translator   string        `yaml:"translator"`
+	Normalize    bool          `yaml:"normalize"`
+	PreAggregate int           `yaml:"pre_aggregate"`
+	MaxTokens    int           `yaml:"max_tokens"`
+	Labels       string        `yaml:"labels"`
+	Model        ModelConfig   `yaml:"model"`
+	History      HistoryConfig `yaml:"history"`
+	Server       ServerConfig  `yaml:"server"`
+	Privacy      PrivacyConfig `yaml:"privacy"`
+}
+
+// configFile struct isi file YAML: profil bawaan bisa diubah atau ditambah. Profiles disimpan
+// mentah agar hanya field yang ditulis yang menimpa profil bawaan.
+type configFile struct {
+	DefaultProfile string                 `yaml:"default_profile"`
+	Profiles       map[string]interface{} `yaml:"profiles"`
+}
+
+// configSchema bentuk file yang dipakai untuk validasi, sehingga field yang salah ketik
+// dilaporkan dengan nomor baris di file aslinya
+type configSchema struct {
+	DefaultProfile string            `yaml:"default_profile"`
+	Profiles       map[string]Config `yaml:"profiles"`
+}
+
+// builtinProfiles profil bawaan: dev memakai fake server, home sama dengan default flag,
+// prod menjalankan HTTP API dengan autentikasi dan batas token
+var builtinProfiles = map[string]Config{
+	"dev": {
+		CSV: "data-series.csv", Translator: "lexicon", Normalize: true,
+		Model:   ModelConfig{Fake: true, MaxRetries: 1, MinConfidence: 0.5},
+		History: HistoryConfig{Path: "dev-history.jsonl"},
+		Server:  ServerConfig{QueueSize: DefaultQueueSize, Concurrency: DefaultConcurrency},
+	},
+	"home": {
+		CSV: "data-series.csv", Translator: "lexicon", Normalize: true, Labels: "labels.jsonl",
+		Model:   ModelConfig{MaxRetries: DefaultMaxRetries, MinConfidence: 0.5},
+		History: HistoryConfig{Path: "history.jsonl"},
+		Server:  ServerConfig{QueueSize: DefaultQueueSize, Concurrency: DefaultConcurrency},
+	},
+	"prod": {
+		CSV: "data-series.csv", Translator: "lexicon", Normalize: true, Labels: "labels.jsonl", MaxTokens: TapasMaxTokens,
+		Model:   ModelConfig{MaxRetries: DefaultMaxRetries, MinConfidence: 0.5},
+		History: HistoryConfig{Path: "history.jsonl", MaxAge: "2160h", MaxEntries: 100000},
+		Server:  ServerConfig{HTTP: ":8080", APIKeys: "api-keys.json", MaxDatasets: 10, MaxRows: 100000, QueueSize: DefaultQueueSize, Concurrency: DefaultConcurrency},
+	},
+}
+
+// configEnv variabel environment yang menimpa isi file konfigurasi
+var configEnv = []struct {
+	name  string
+	apply func(c *Config, v string) error
+}{
+	{"TABLEQA_CSV", func(c *Config, v string) error { c.CSV = v; return nil }},
+	{"TABLEQA_MODEL_URL", func(c *Config, v string) error { c.Model.URL = v; return nil }},
+	{"TABLEQA_MAX_RETRIES", func(c *Config, v string) error { return parseConfigInt(v, &c.Model.MaxRetries) }},
+	{"HUGGINGFACE_TOKEN", func(c *Config, v string) error { c.Model.Token = v; return nil }},
+	{"TABLEQA_HISTORY", func(c *Config, v string) error { c.History.Path = v; return nil }},
+	{"TABLEQA_HTTP", func(c *Config, v string) error { c.Server.HTTP = v; return nil }},
+	{"TABLEQA_GRPC", func(c *Config, v string) error { c.Server.GRPC = v; return nil }},
+}
+
+func parseConfigInt(v string, dst *int) error {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return errors.New(T("config.not_a_number", v))
+	}
+	*dst = n
+	return nil
+}
+
+// LoadConfig membaca profil dari file YAML (boleh tidak ada jika required false), lalu menerapkan
+// environment override dari getenv. Profil dipilih dari argumen profile, TABLEQA_PROFILE,
+// default_profile di file, lalu "home".
+func LoadConfig(path, profile string, required bool, getenv func(string) string) (Config, error) {
+	var file configFile
+	data, err := ReadDataFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.UnmarshalStrict(data, &configSchema{}); err != nil {
+			return Config{}, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return Config{}, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+	case errors.Is(err, os.ErrNotExist) && !required:
+	default:
+		return Config{}, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	for _, name := range []string{profile, getenv("TABLEQA_PROFILE"), file.DefaultProfile, "home"} {
+		if name != "" {
+			profile = name
+			break
+		}
+	}
+	cfg, builtin := builtinProfiles[profile]
+	override, inFile := file.Profiles[profile]
+	if !builtin && !inFile {
+		return Config{}, fmt.Errorf("%w: %s", ErrInvalidConfig, T("config.unknown_profile", profile, strings.Join(profileNames(file), ", ")))
+	}
+	if !builtin {
+		cfg = builtinProfiles["home"]
+	}
+	if inFile {
+		// Isi profil di file hanya menimpa field yang ditulis; sisanya dari profil bawaan
+		data, err := yaml.Marshal(override)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("%w: %s: %s", ErrInvalidConfig, path, T("config.profile_error", profile, err))
+		}
+	}
+	cfg.Profile = profile
+
+	for _, env := range configEnv {
+		if v := getenv(env.name); v != "" {
+			if err := env.apply(&cfg, v); err != nil {
+				return Config{}, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, env.name, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func profileNames(file configFile) []string {
+	var names []string
+	for name := range builtinProfiles {
+		names = append(names, name)
+	}
+	for name := range file.Profiles {
+		if _, ok := builtinProfiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate memeriksa semua field dan mengembalikan seluruh masalah sekaligus
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, problem string) {
+		if !ok {
+			problems = append(problems, problem)
+		}
+	}
+
+	check(c.CSV != "", T("config.csv_required"))
+	if c.CSV != "" {
+		_, err := os.Stat(c.CSV)
+		check(err == nil, T("config.csv_missing", c.CSV))
+	}
+	check(c.Lang == "" || containsString(Locales(), c.Lang), T("config.lang", strings.Join(Locales(), ", ")))
+	check(containsString([]string{"lexicon", "hf", "none"}, c.Translator), T("config.translator", c.Translator))
+	check(c.PreAggregate >= 0, T("config.negative", "pre_aggregate"))
+	check(c.MaxTokens >= 0, T("config.negative", "max_tokens"))
+
+	check(c.Model.MaxRetries >= 1, T("config.at_least_one", "model.max_retries"))
+	check(c.Model.MinConfidence >= 0 && c.Model.MinConfidence <= 1, T("config.min_confidence"))
+	check(c.Model.URL == "" || strings.HasPrefix(c.Model.URL, "http://") || strings.HasPrefix(c.Model.URL, "https://"), T("config.model_url"))
+	check(!(c.Model.Fake && c.Model.ONNX != ""), T("config.fake_onnx"))
+	check(c.Model.Token != "" || c.Model.Fake || c.Model.ONNX != "", T("config.token_required"))
+
+	if c.History.MaxAge != "" {
+		d, err := time.ParseDuration(c.History.MaxAge)
+		check(err == nil && d >= 0, T("config.max_age", c.History.MaxAge))
+	}
+	check(c.History.MaxEntries >= 0, T("config.negative", "history.max_entries"))
+
+	check(c.Server.MaxDatasets >= 0, T("config.negative", "server.max_datasets"))
+	check(c.Server.MaxRows >= 0, T("config.negative", "server.max_rows"))
+	check(c.Server.QueueSize >= 0, T("config.negative", "server.queue_size"))
+	check(c.Server.Concurrency >= 1, T("config.at_least_one", "server.concurrency"))
+	if _, err := ParseBackendLimits(c.Server.BackendConcurrency); err != nil {
+		check(false, T("config.field_error", "server.backend_concurrency", err))
+	}
+	if c.Server.APIKeys != "" && (c.Server.HTTP != "" || c.Server.GRPC != "") {
+		_, err := os.Stat(c.Server.APIKeys)
+		check(err == nil, T("config.api_keys_missing", c.Server.APIKeys))
+	}
+	if c.Privacy.Columns != "" {
+		_, err := ParsePrivacy(c.Privacy.Columns)
+		check(err == nil, T("config.field_error", "privacy.columns", err))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s\n  %s", ErrInvalidConfig, T("config.profile_problems", c.Profile), strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// flagFields menghubungkan nama flag command line dengan field Config
+func (c *Config) flagFields() map[string]interface{} {
+	return map[string]interface{}{
+		"csv":                 &c.CSV,
+		"lang":                &c.Lang,
+		"translator":          &c.Translator,
+		"normalize":           &c.Normalize,
+		"pre-aggregate":       &c.PreAggregate,
+		"max-tokens":          &c.MaxTokens,
+		"labels":              &c.Labels,
+		"model-url":           &c.Model.URL,
+		"fake-model":          &c.Model.Fake,
+		"max-retries":         &c.Model.MaxRetries,
+		"sqa":                 &c.Model.SQA,
+		"fallback-models":     &c.Model.FallbackModels,
+		"min-confidence":      &c.Model.MinConfidence,
+		"onnx-model":          &c.Model.ONNX,
+		"onnx-library":        &c.Model.ONNXLibrary,
+		"history":             &c.History.Path,
+		"history-max-age":     &c.History.MaxAge,
+		"history-max-entries": &c.History.MaxEntries,
+		"grpc":                &c.Server.GRPC,
+		"http":                &c.Server.HTTP,
+		"api-keys":            &c.Server.APIKeys,
+		"default-owner":       &c.Server.DefaultOwner,
+		"default-tenant":      &c.Server.DefaultTenant,
+		"max-datasets":        &c.Server.MaxDatasets,
+		"max-rows":            &c.Server.MaxRows,
+		"queue-size":          &c.Server.QueueSize,
+		"concurrency":         &c.Server.Concurrency,
+		"backend-concurrency": &c.Server.BackendConcurrency,
+		"privacy":             &c.Privacy.Columns,
+		"privacy-date-shift":  &c.Privacy.DateShift,
+	}
+}
+
+// ApplyConfig mengisi flag di fs yang tidak diisi user dengan nilai dari konfigurasi, lalu
+// mengembalikan konfigurasi efektif yang sudah memuat flag yang diisi user
+func ApplyConfig(fs *flag.FlagSet, c Config) (Config, error) {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for name, field := range c.flagFields() {
+		f := fs.Lookup(name)
+		if f == nil {
+			continue
+		}
+		if set[name] {
+			if err := setConfigField(field, f.Value.String()); err != nil {
+				return c, fmt.Errorf("%w: -%s: %v", ErrInvalidConfig, name, err)
+			}
+			continue
+		}
+		value := fmt.Sprint(fieldValue(field))
+		if name == "history-max-age" && value == "" {
+			value = "0"
+		}
+		if err := fs.Set(name, value); err != nil {
+			return c, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, name, err)
+		}
+	}
+	return c, nil
+}
+
+// fieldValue nilai yang ditunjuk pointer field Config
+func fieldValue(field interface{}) interface{} {
+	switch v := field.(type) {
+	case *string:
+		return *v
+	case *int:
+		return *v
+	case *bool:
+		return *v
+	case *float64:
+		return *v
+	}
+	return nil
+}
+
+// setConfigField mengisi field Config dari nilai flag dalam bentuk teks
+func setConfigField(field interface{}, value string) error {
+	var err error
+	switch v := field.(type) {
+	case *string:
+		*v = value
+	case *int:
+		*v, err = strconv.Atoi(value)
+	case *bool:
+		*v, err = strconv.ParseBool(value)
+	case *float64:
+		*v, err = strconv.ParseFloat(value, 64)
+	}
+	return err
+}
+
+// maskSecret menyembunyikan secret kecuali 4 karakter terakhir
+func maskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 8 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
+
+// PrintConfig menulis konfigurasi efektif sebagai YAML dengan secret disamarkan
+func PrintConfig(out io.Writer, c Config) error {
+	c.Model.Token = maskSecret(c.Model.Token)
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// runConfig menjalankan perintah "config show": konfigurasi efektif profil aktif beserta hasil validasinya
+func runConfig(args []string, cfg Config) error {
+	if len(args) == 0 || args[0] != "show" {
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, T("config.usage"))
+	}
+	if err := PrintConfig(os.Stdout, cfg); err != nil {
+		return err
+	}
+	return cfg.Validate()
+}
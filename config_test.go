@@ -0,0 +1,149 @@
+package main_test
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Configuration", func() {
+	var dir string
+	noEnv := func(string) string { return "" }
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(dir, "tableqa.yaml")
+		Expect(ioutil.WriteFile(path, []byte(content), 0o600)).Should(Succeed())
+		return path
+	}
+
+	It("uses the built-in profiles when there is no config file", func() {
+		cfg, err := main.LoadConfig(filepath.Join(dir, "missing.yaml"), "", false, noEnv)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Profile).Should(Equal("home"))
+		Expect(cfg.Model.MaxRetries).Should(Equal(main.DefaultMaxRetries))
+		Expect(cfg.History.Path).Should(Equal("history.jsonl"))
+
+		cfg, err = main.LoadConfig(filepath.Join(dir, "missing.yaml"), "dev", false, noEnv)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Model.Fake).Should(BeTrue())
+
+		_, err = main.LoadConfig(filepath.Join(dir, "missing.yaml"), "", true, noEnv)
+		Expect(err).Should(MatchError(main.ErrInvalidConfig))
+		_, err = main.LoadConfig(filepath.Join(dir, "missing.yaml"), "staging", false, noEnv)
+		Expect(err).Should(MatchError(ContainSubstring(`unknown profile "staging", available: dev, home, prod`)))
+	})
+
+	It("overrides only the fields written in the file, then the environment", func() {
+		path := writeConfig(`
+default_profile: home
+profiles:
+  home:
+    csv: home.csv
+    model:
+      max_retries: 3
+  cabin:
+    history:
+      path: ""
+`)
+		cfg, err := main.LoadConfig(path, "", false, noEnv)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.CSV).Should(Equal("home.csv"))
+		Expect(cfg.Model.MaxRetries).Should(Equal(3))
+		Expect(cfg.Model.MinConfidence).Should(Equal(0.5))
+
+		env := map[string]string{"TABLEQA_PROFILE": "cabin", "HUGGINGFACE_TOKEN": "hf_secret_token", "TABLEQA_MAX_RETRIES": "5"}
+		cfg, err = main.LoadConfig(path, "", false, func(k string) string { return env[k] })
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Profile).Should(Equal("cabin"))
+		Expect(cfg.History.Path).Should(BeEmpty())
+		Expect(cfg.Labels).Should(Equal("labels.jsonl"))
+		Expect(cfg.Model.Token).Should(Equal("hf_secret_token"))
+		Expect(cfg.Model.MaxRetries).Should(Equal(5))
+
+		env["TABLEQA_MAX_RETRIES"] = "many"
+		_, err = main.LoadConfig(path, "", false, func(k string) string { return env[k] })
+		Expect(err).Should(MatchError(ContainSubstring(`TABLEQA_MAX_RETRIES: "many" is not a number`)))
+	})
+
+	It("reports misspelled fields with their line number", func() {
+		path := writeConfig("profiles:\n  home:\n    model:\n      max_retrys: 3\n")
+		_, err := main.LoadConfig(path, "", false, noEnv)
+		Expect(err).Should(MatchError(main.ErrInvalidConfig))
+		Expect(err).Should(MatchError(ContainSubstring("line 4: field max_retrys not found")))
+	})
+
+	It("validates every field at once", func() {
+		cfg, _ := main.LoadConfig(filepath.Join(dir, "missing.yaml"), "home", false, noEnv)
+		cfg.CSV = filepath.Join(dir, "nope.csv")
+		cfg.Translator = "google"
+		cfg.Model.MaxRetries = 0
+		cfg.History.MaxAge = "a month"
+		err := cfg.Validate()
+		Expect(err).Should(MatchError(main.ErrInvalidConfig))
+		for _, problem := range []string{"csv: file", "translator: must be lexicon, hf or none", "model.max_retries", "model.token: required", "history.max_age"} {
+			Expect(err.Error()).Should(ContainSubstring(problem))
+		}
+
+		cfg, _ = main.LoadConfig(filepath.Join(dir, "missing.yaml"), "dev", false, noEnv)
+		cfg.CSV = "data-series.csv"
+		Expect(cfg.Validate()).Should(Succeed())
+	})
+
+	It("fills flags that were not set and keeps the ones that were", func() {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		retries := fs.Int("max-retries", main.DefaultMaxRetries, "")
+		history := fs.String("history", "history.jsonl", "")
+		maxAge := fs.Duration("history-max-age", 0, "")
+		Expect(fs.Parse([]string{"-history", "mine.jsonl"})).Should(Succeed())
+
+		cfg, _ := main.LoadConfig(filepath.Join(dir, "missing.yaml"), "prod", false, noEnv)
+		cfg.Model.MaxRetries = 4
+		effective, err := main.ApplyConfig(fs, cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*retries).Should(Equal(4))
+		Expect(*history).Should(Equal("mine.jsonl"))
+		Expect(maxAge.Hours()).Should(Equal(2160.0))
+		Expect(effective.History.Path).Should(Equal("mine.jsonl"))
+	})
+
+	It("masks secrets when printing", func() {
+		cfg, _ := main.LoadConfig(filepath.Join(dir, "missing.yaml"), "home", false, func(k string) string {
+			if k == "HUGGINGFACE_TOKEN" {
+				return "hf_abcdefghijklmnop"
+			}
+			return ""
+		})
+		var out bytes.Buffer
+		Expect(main.PrintConfig(&out, cfg)).Should(Succeed())
+		Expect(out.String()).Should(ContainSubstring("token: '****mnop'"))
+		Expect(out.String()).ShouldNot(ContainSubstring("abcdefgh"))
+	})
+
+	It("stops retrying a loading model after MaxRetries", func() {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"loading","estimated_time":0}`))
+		}))
+		defer server.Close()
+
+		connector := &main.AIModelConnector{Client: server.Client(), URL: server.URL, MaxRetries: 2}
+		_, err := connector.ConnectAIModel(main.Inputs{Query: "How much?"}, "token")
+		Expect(err).Should(MatchError(main.ErrMaxRetries))
+		Expect(atomic.LoadInt32(&calls)).Should(Equal(int32(2)))
+	})
+})
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRange struct rentang tanggal inklusif
+type DateRange struct {
+	From time.Time
+	To   time.Time
+	// Source potongan pertanyaan yang menghasilkan rentang ini
+	Source string
+}
+
+// DateFilter struct filter baris berdasarkan tanggal yang disebut di pertanyaan
+type DateFilter struct {
+	Column string
+	Ranges []DateRange
+}
+
+// String menampilkan filter dalam bentuk yang mudah dibaca untuk history dan explain
+func (f DateFilter) String() string {
+	parts := make([]string, len(f.Ranges))
+	for i, r := range f.Ranges {
+		if r.From.Equal(r.To) {
+			parts[i] = fmt.Sprintf("%s = %s (%q)", f.Column, r.From.Format(dateLayout), r.Source)
+		} else {
+			parts[i] = fmt.Sprintf("%s in %s..%s (%q)", f.Column, r.From.Format(dateLayout), r.To.Format(dateLayout), r.Source)
+		}
+	}
+	return strings.Join(parts, " or ")
+}
+
+const dateLayout = "2006-01-02"
+
+var monthNames = map[string]time.Month{
+	"january": time.January, "february": time.February, "march": time.March,
+	"april": time.April, "may": time.May, "june": time.June,
+	"july": time.July, "august": time.August, "september": time.September,
+	"october": time.October, "november": time.November, "december": time.December,
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"jun": time.June, "jul": time.July, "aug": time.August, "sep": time.September,
+	"sept": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+var (
+	isoDatePattern      = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	dayMonthYearPattern = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+([a-z]+)\s+(\d{4})\b`)
+	monthDayYearPattern = regexp.MustCompile(`(?i)\b([a-z]+)\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`)
+	monthYearPattern    = regexp.MustCompile(`(?i)\b([a-z]+)\s+(\d{4})\b`)
+	yearPattern         = regexp.MustCompile(`\b((?:19|20)\d{2})\b`)
+)
+
+// ResolveDateFilter mencari penyebutan tanggal, bulan, atau tahun di pertanyaan.
+// Potongan yang sudah dipakai pola yang lebih spesifik tidak dicocokkan lagi.
+func ResolveDateFilter(query string) (DateFilter, bool) {
+	var ranges []DateRange
+	rest := query
+
+	consume := func(loc []int) {
+		rest = rest[:loc[0]] + strings.Repeat(" ", loc[1]-loc[0]) + rest[loc[1]:]
+	}
+
+	for _, loc := range isoDatePattern.FindAllStringSubmatchIndex(rest, -1) {
+		day, err := time.Parse(dateLayout, rest[loc[0]:loc[1]])
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, DateRange{From: day, To: day, Source: rest[loc[0]:loc[1]]})
+	}
+	for _, loc := range isoDatePattern.FindAllStringIndex(rest, -1) {
+		consume(loc)
+	}
+
+	for _, pattern := range []*regexp.Regexp{dayMonthYearPattern, monthDayYearPattern} {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(rest, -1) {
+			a, b, y := rest[loc[2]:loc[3]], rest[loc[4]:loc[5]], rest[loc[6]:loc[7]]
+			dayText, monthText := a, b
+			if pattern == monthDayYearPattern {
+				dayText, monthText = b, a
+			}
+			month, ok := monthNames[strings.ToLower(monthText)]
+			if !ok {
+				continue
+			}
+			dayNum, _ := strconv.Atoi(dayText)
+			year, _ := strconv.Atoi(y)
+			day := time.Date(year, month, dayNum, 0, 0, 0, 0, time.UTC)
+			if day.Month() != month {
+				continue
+			}
+			ranges = append(ranges, DateRange{From: day, To: day, Source: rest[loc[0]:loc[1]]})
+			consume(loc[:2])
+		}
+	}
+
+	for _, loc := range monthYearPattern.FindAllStringSubmatchIndex(rest, -1) {
+		month, ok := monthNames[strings.ToLower(rest[loc[2]:loc[3]])]
+		if !ok {
+			continue
+		}
+		year, _ := strconv.Atoi(rest[loc[4]:loc[5]])
+		from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		ranges = append(ranges, DateRange{From: from, To: from.AddDate(0, 1, -1), Source: rest[loc[0]:loc[1]]})
+		consume(loc[:2])
+	}
+
+	for _, loc := range yearPattern.FindAllStringSubmatchIndex(rest, -1) {
+		year, _ := strconv.Atoi(rest[loc[2]:loc[3]])
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		ranges = append(ranges, DateRange{From: from, To: from.AddDate(1, 0, -1), Source: rest[loc[0]:loc[1]]})
+	}
+
+	if len(ranges) == 0 {
+		return DateFilter{}, false
+	}
+	return DateFilter{Column: "Date", Ranges: ranges}, true
+}
+
+// dateColumn mencari nama kolom tanggal di tabel (tidak peka huruf besar/kecil)
+func dateColumn(table map[string][]string) (string, bool) {
+	for col := range table {
+		if strings.EqualFold(col, "date") {
+			return col, true
+		}
+	}
+	return "", false
+}
+
+// ApplyDateFilter mengembalikan tabel baru yang hanya berisi baris dalam salah satu rentang
+func ApplyDateFilter(table map[string][]string, f DateFilter) (map[string][]string, int) {
+	keep := dateFilterRows(table, f)
+	return selectRows(table, keep), len(keep)
+}
+
+// dateFilterRows mengembalikan indeks baris yang tanggalnya masuk salah satu rentang
+func dateFilterRows(table map[string][]string, f DateFilter) []int {
+	values := table[f.Column]
+	keep := make([]int, 0, len(values))
+	for i, v := range values {
+		day, err := time.Parse(dateLayout, strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		for _, r := range f.Ranges {
+			if !day.Before(r.From) && !day.After(r.To) {
+				keep = append(keep, i)
+				break
+			}
+		}
+	}
+	return keep
+}
+
+// selectRows membuat tabel baru dari indeks baris yang dipilih
+func selectRows(table map[string][]string, rows []int) map[string][]string {
+	result := make(map[string][]string, len(table))
+	for col, values := range table {
+		selected := make([]string, 0, len(rows))
+		for _, i := range rows {
+			if i < len(values) {
+				selected = append(selected, values[i])
+			}
+		}
+		result[col] = selected
+	}
+	return result
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultQueueSize jumlah pemanggilan model yang boleh menunggu sebelum ditolak dengan ErrOverloaded
+	DefaultQueueSize = 64
+	// DefaultConcurrency pemanggilan bersamaan per backend
+	DefaultConcurrency = 4
+)
+
+// ErrOverloaded dikembalikan jika antrean dispatcher penuh; pemanggil sebaiknya mencoba lagi nanti
+var ErrOverloaded = messageError("error.overloaded")
+
+// Priority prioritas sebuah pertanyaan di antrean dispatcher, nilai lebih besar dilayani lebih dulu
+type Priority int
+
+const (
+	// PriorityBatch untuk batch dan eval yang boleh menunggu
+	PriorityBatch Priority = iota
+	// PriorityInteractive untuk REPL dan request server yang ditunggu user, default jika tidak diisi
+	PriorityInteractive
+)
+
+type priorityKey struct{}
+
+// WithPriority menyisipkan prioritas ke context yang dipakai untuk Backend.Ask
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext mengambil prioritas dari context, PriorityInteractive jika tidak ada
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// DispatcherStats struct jumlah pemanggilan yang sedang berjalan dan menunggu
+type DispatcherStats struct {
+	Running map[string]int
+	Queued  int
+}
+
+// Dispatcher struct pembatas pemanggilan model yang dipakai bersama semua goroutine. Setiap backend
+// (berdasarkan Name) punya batas pemanggilan bersamaan; pemanggilan di atas batas menunggu di satu
+// antrean berbatas yang mendahulukan prioritas lebih tinggi lalu yang datang lebih dulu. Jika antrean
+// penuh, pemanggilan langsung ditolak dengan ErrOverloaded.
+type Dispatcher struct {
+	// QueueSize jumlah maksimal pemanggilan yang menunggu di semua backend, 0 berarti tidak boleh menunggu
+	QueueSize int
+	// DefaultLimit batas pemanggilan bersamaan per backend, minimal 1
+	DefaultLimit int
+	// Limits batas per nama backend yang menimpa DefaultLimit
+	Limits map[string]int
+
+	mu      sync.Mutex
+	running map[string]int
+	waiting map[string]*waitQueue
+	queued  int
+	seq     uint64
+}
+
+// NewDispatcher membuat Dispatcher dengan antrean queueSize dan batas limit per backend
+func NewDispatcher(queueSize, limit int, limits map[string]int) *Dispatcher {
+	return &Dispatcher{QueueSize: queueSize, DefaultLimit: limit, Limits: limits}
+}
+
+// NewDispatcherFromConfig membuat Dispatcher dari server.queue_size, server.concurrency, dan
+// server.backend_concurrency sehingga REPL, server, batch, dan eval memakai batas yang sama
+func NewDispatcherFromConfig(cfg ServerConfig) (*Dispatcher, error) {
+	limits, err := ParseBackendLimits(cfg.BackendConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	return NewDispatcher(cfg.QueueSize, cfg.Concurrency, limits), nil
+}
+
+// ParseBackendLimits membaca batas per backend (lihat Backend.Name) seperti "hf:google/tapas-large-finetuned-wtq=1,local:tapas.onnx=2"
+func ParseBackendLimits(spec string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		// Nama backend bisa berisi '=' di URL, jadi angka diambil dari '=' terakhir
+		i := strings.LastIndex(part, "=")
+		if i <= 0 {
+			return nil, errors.New(T("dispatcher.limit_syntax", part))
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(part[i+1:]))
+		if err != nil || n < 1 {
+			return nil, errors.New(T("dispatcher.limit_value", part))
+		}
+		limits[strings.TrimSpace(part[:i])] = n
+	}
+	return limits, nil
+}
+
+func (d *Dispatcher) limit(backend string) int {
+	if n, ok := d.Limits[backend]; ok && n > 0 {
+		return n
+	}
+	if d.DefaultLimit > 0 {
+		return d.DefaultLimit
+	}
+	return 1
+}
+
+// Acquire menunggu giliran memanggil backend. release wajib dipanggil setelah pemanggilan selesai.
+// Mengembalikan ErrOverloaded jika antrean penuh atau ctx.Err() jika ctx dibatalkan saat menunggu.
+func (d *Dispatcher) Acquire(ctx context.Context, backend string) (release func(), err error) {
+	d.mu.Lock()
+	if d.running == nil {
+		d.running = make(map[string]int)
+		d.waiting = make(map[string]*waitQueue)
+	}
+	release = func() { d.release(backend) }
+	q := d.waiting[backend]
+	if d.running[backend] < d.limit(backend) && (q == nil || q.Len() == 0) {
+		d.running[backend]++
+		d.mu.Unlock()
+		return release, nil
+	}
+	if d.queued >= d.QueueSize {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrOverloaded, T("dispatcher.overloaded_detail", d.queued, d.running[backend], backend))
+	}
+	if q == nil {
+		q = &waitQueue{}
+		d.waiting[backend] = q
+	}
+	d.seq++
+	w := &waiter{priority: PriorityFromContext(ctx), seq: d.seq, ready: make(chan struct{})}
+	heap.Push(q, w)
+	d.queued++
+	d.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return release, nil
+	case <-ctx.Done():
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if w.index < 0 {
+		// Giliran sudah diberikan bersamaan dengan pembatalan, teruskan ke yang berikutnya
+		d.next(backend)
+		return nil, ctx.Err()
+	}
+	heap.Remove(q, w.index)
+	d.queued--
+	return nil, ctx.Err()
+}
+
+func (d *Dispatcher) release(backend string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.next(backend)
+}
+
+// next memberikan slot yang baru kosong ke waiter berikutnya; dipanggil dengan mu terkunci
+func (d *Dispatcher) next(backend string) {
+	q := d.waiting[backend]
+	if q == nil || q.Len() == 0 {
+		d.running[backend]--
+		return
+	}
+	w := heap.Pop(q).(*waiter)
+	d.queued--
+	close(w.ready)
+}
+
+// Stats mengembalikan jumlah pemanggilan yang sedang berjalan per backend dan yang menunggu
+func (d *Dispatcher) Stats() DispatcherStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := DispatcherStats{Running: make(map[string]int), Queued: d.queued}
+	for name, n := range d.running {
+		if n > 0 {
+			stats.Running[name] = n
+		}
+	}
+	return stats
+}
+
+// Wrap membungkus backend agar setiap Ask melewati dispatcher ini
+func (d *Dispatcher) Wrap(b Backend) Backend {
+	return &DispatchedBackend{Backend: b, Dispatcher: d}
+}
+
+// DispatchedBackend struct Backend yang menunggu giliran dari Dispatcher sebelum memanggil backend
+// aslinya. Nama backend tidak berubah sehingga history dan laporan tetap sama.
+type DispatchedBackend struct {
+	Backend    Backend
+	Dispatcher *Dispatcher
+}
+
+// Name mengembalikan nama backend yang dibungkus
+func (b *DispatchedBackend) Name() string {
+	return b.Backend.Name()
+}
+
+// Ask menunggu slot untuk backend lalu meneruskan payload
+func (b *DispatchedBackend) Ask(ctx context.Context, payload Inputs) (Response, error) {
+	release, err := b.Dispatcher.Acquire(ctx, b.Backend.Name())
+	if err != nil {
+		return Response{}, err
+	}
+	defer release()
+	return b.Backend.Ask(ctx, payload)
+}
+
+// waiter satu pemanggilan yang menunggu; index -1 berarti sudah keluar dari antrean
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+// waitQueue heap waiter berdasarkan prioritas lalu urutan datang
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() interface{} {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,233 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// gatedBackend menahan setiap Ask sampai gate ditutup dan mencatat jumlah Ask bersamaan
+type gatedBackend struct {
+	name    string
+	gate    chan struct{}
+	mu      sync.Mutex
+	running int
+	peak    int
+	order   []string
+}
+
+func (b *gatedBackend) Name() string { return b.name }
+
+func (b *gatedBackend) Ask(ctx context.Context, payload main.Inputs) (main.Response, error) {
+	b.mu.Lock()
+	b.running++
+	if b.running > b.peak {
+		b.peak = b.running
+	}
+	b.order = append(b.order, payload.Query)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.running--
+		b.mu.Unlock()
+	}()
+	select {
+	case <-b.gate:
+		return main.Response{Answer: payload.Query}, nil
+	case <-ctx.Done():
+		return main.Response{}, ctx.Err()
+	}
+}
+
+func (b *gatedBackend) asked() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.order...)
+}
+
+var _ = Describe("Dispatcher", func() {
+	It("limits concurrent calls per backend and rejects when the queue is full", func() {
+		dispatcher := main.NewDispatcher(2, 1, map[string]int{"fast": 2})
+		slow := &gatedBackend{name: "slow", gate: make(chan struct{})}
+		fast := &gatedBackend{name: "fast", gate: make(chan struct{})}
+		ask := func(b main.Backend, query string) <-chan error {
+			errc := make(chan error, 1)
+			go func() {
+				_, err := b.Ask(context.Background(), main.Inputs{Query: query})
+				errc <- err
+			}()
+			return errc
+		}
+
+		first := ask(dispatcher.Wrap(slow), "1")
+		Eventually(slow.asked).Should(HaveLen(1))
+		queued := []<-chan error{ask(dispatcher.Wrap(slow), "2"), ask(dispatcher.Wrap(slow), "3")}
+		Eventually(func() int { return dispatcher.Stats().Queued }).Should(Equal(2))
+
+		_, err := dispatcher.Wrap(slow).Ask(context.Background(), main.Inputs{Query: "4"})
+		Expect(err).Should(MatchError(main.ErrOverloaded))
+
+		// Backend lain punya batas sendiri tetapi antrean dipakai bersama
+		fastDone := []<-chan error{ask(dispatcher.Wrap(fast), "a"), ask(dispatcher.Wrap(fast), "b")}
+		Eventually(fast.asked).Should(HaveLen(2))
+		Expect(dispatcher.Stats().Running).Should(Equal(map[string]int{"slow": 1, "fast": 2}))
+		close(fast.gate)
+		for _, errc := range fastDone {
+			Expect(<-errc).Should(Succeed())
+		}
+
+		close(slow.gate)
+		Expect(<-first).Should(Succeed())
+		for _, errc := range queued {
+			Expect(<-errc).Should(Succeed())
+		}
+		Expect(slow.peak).Should(Equal(1))
+		Expect(dispatcher.Stats()).Should(Equal(main.DispatcherStats{Running: map[string]int{}}))
+	})
+
+	It("serves interactive questions before batch questions", func() {
+		dispatcher := main.NewDispatcher(10, 1, nil)
+		backend := &gatedBackend{name: "hf", gate: make(chan struct{})}
+		var wg sync.WaitGroup
+		ask := func(ctx context.Context, query string) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				dispatcher.Wrap(backend).Ask(ctx, main.Inputs{Query: query})
+			}()
+		}
+
+		ask(context.Background(), "running")
+		Eventually(backend.asked).Should(HaveLen(1))
+		batch := main.WithPriority(context.Background(), main.PriorityBatch)
+		ask(batch, "batch 1")
+		Eventually(func() int { return dispatcher.Stats().Queued }).Should(Equal(1))
+		ask(batch, "batch 2")
+		Eventually(func() int { return dispatcher.Stats().Queued }).Should(Equal(2))
+		ask(context.Background(), "interactive")
+		Eventually(func() int { return dispatcher.Stats().Queued }).Should(Equal(3))
+
+		close(backend.gate)
+		wg.Wait()
+		Expect(backend.asked()).Should(Equal([]string{"running", "interactive", "batch 1", "batch 2"}))
+	})
+
+	It("removes cancelled calls from the queue", func() {
+		dispatcher := main.NewDispatcher(1, 1, nil)
+		backend := &gatedBackend{name: "hf", gate: make(chan struct{})}
+		go dispatcher.Wrap(backend).Ask(context.Background(), main.Inputs{Query: "running"})
+		Eventually(backend.asked).Should(HaveLen(1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := dispatcher.Wrap(backend).Ask(ctx, main.Inputs{Query: "gave up"})
+		Expect(err).Should(MatchError(context.DeadlineExceeded))
+		Expect(dispatcher.Stats().Queued).Should(BeZero())
+
+		close(backend.gate)
+		Expect(dispatcher.Wrap(backend).Ask(context.Background(), main.Inputs{Query: "next"})).Should(HaveField("Answer", "next"))
+		Expect(backend.asked()).Should(Equal([]string{"running", "next"}))
+	})
+
+	It("parses per-backend limits", func() {
+		limits, err := main.ParseBackendLimits("hf:google/tapas-large-finetuned-wtq=1, hf:http://localhost:8000/?a=b=3")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(limits).Should(Equal(map[string]int{"hf:google/tapas-large-finetuned-wtq": 1, "hf:http://localhost:8000/?a=b": 3}))
+		_, err = main.ParseBackendLimits("hf=0")
+		Expect(err).Should(HaveOccurred())
+		_, err = main.ParseBackendLimits("hf")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("shares one AIModelConnector between many goroutines", func() {
+		var inFlight, peak int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			var in main.Inputs
+			json.NewDecoder(r.Body).Decode(&in)
+			time.Sleep(10 * time.Millisecond)
+			json.NewEncoder(w).Encode(main.Response{Answer: in.Query, Aggregator: "NONE"})
+		}))
+		defer server.Close()
+
+		connector := &main.AIModelConnector{URL: server.URL}
+		backend := main.NewDispatcher(20, 3, nil).Wrap(&main.HFBackend{Connector: connector, Token: "token"})
+		var wg sync.WaitGroup
+		answers := make([]string, 12)
+		for i := range answers {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp, err := backend.Ask(context.Background(), main.Inputs{Query: fmt.Sprint(i)})
+				if err == nil {
+					answers[i] = resp.Answer
+				}
+			}(i)
+		}
+		wg.Wait()
+		for i, answer := range answers {
+			Expect(answer).Should(Equal(fmt.Sprint(i)))
+		}
+		Expect(atomic.LoadInt32(&peak)).Should(BeNumerically("<=", 3))
+	})
+
+	It("answers batch questions with several workers in question order", func() {
+		backend := &gatedBackend{name: "hf", gate: make(chan struct{})}
+		close(backend.gate)
+		table := map[string][]string{"Appliance": {"TV"}, "Energy_Consumption": {"0.5"}}
+		questions := "q1\nq2\nq3\nq4\nq5\n"
+		var out bytes.Buffer
+		Expect(main.RunBatch(context.Background(), &main.Pipeline{Backend: backend}, table, "", 3, strings.NewReader(questions), &out)).Should(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).Should(HaveLen(5))
+		for i, line := range lines {
+			var result main.BatchResult
+			Expect(json.Unmarshal([]byte(line), &result)).Should(Succeed())
+			Expect(result.Question).Should(Equal(fmt.Sprintf("q%d", i+1)))
+		}
+		Expect(backend.peak).Should(BeNumerically("<=", 3))
+	})
+
+	It("applies the configured backend limit to batch workers", func() {
+		dispatcher, err := main.NewDispatcherFromConfig(main.ServerConfig{QueueSize: 8, Concurrency: 4, BackendConcurrency: "hf=1"})
+		Expect(err).ShouldNot(HaveOccurred())
+		backend := &gatedBackend{name: "hf", gate: make(chan struct{})}
+		table := map[string][]string{"Appliance": {"TV"}, "Energy_Consumption": {"0.5"}}
+		var out bytes.Buffer
+		done := make(chan error, 1)
+		go func() {
+			done <- main.RunBatch(context.Background(), &main.Pipeline{Backend: dispatcher.Wrap(backend)}, table, "", 4, strings.NewReader("q1\nq2\nq3\nq4\n"), &out)
+		}()
+
+		// Satu pertanyaan berjalan, sisanya menunggu di antrean dispatcher
+		Eventually(func() int { return dispatcher.Stats().Queued }).Should(Equal(3))
+		Expect(dispatcher.Stats().Running).Should(Equal(map[string]int{"hf": 1}))
+		close(backend.gate)
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(backend.peak).Should(Equal(1))
+		Expect(strings.Count(out.String(), "\n")).Should(Equal(4))
+
+		_, err = main.NewDispatcherFromConfig(main.ServerConfig{BackendConcurrency: "hf"})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrEncryptionKey dikembalikan jika kunci enkripsi tidak valid atau file terenkripsi dibaca tanpa kunci
+var ErrEncryptionKey = messageError("error.encryption_key")
+
+// ErrDecrypt dikembalikan jika data terenkripsi tidak bisa dibuka, misalnya karena kuncinya salah
+var ErrDecrypt = messageError("error.decrypt")
+
+const (
+	// EncryptionKeyEnv variabel environment berisi kunci AES-256 (base64 atau hex)
+	EncryptionKeyEnv = "TABLEQA_ENCRYPTION_KEY"
+	// EncryptionKeyFileEnv variabel environment berisi path file kunci
+	EncryptionKeyFileEnv = "TABLEQA_ENCRYPTION_KEY_FILE"
+
+	// encryptedFileMagic awal file yang seluruh isinya terenkripsi (CSV, laporan)
+	encryptedFileMagic = "TQAENC1\n"
+	// encryptedLinePrefix awal satu baris JSONL terenkripsi; file append-only dienkripsi per baris
+	encryptedLinePrefix = "enc1:"
+)
+
+// Cipher struct AES-256-GCM untuk data yang disimpan di disk. Setiap Seal memakai nonce acak.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher membuat Cipher dari kunci 32 byte
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%w: %s", ErrEncryptionKey, T("encrypt.key_length", len(key)))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// GenerateEncryptionKey membuat kunci acak baru dalam bentuk base64
+func GenerateEncryptionKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseEncryptionKey membaca kunci 32 byte dalam bentuk base64 atau hex
+func ParseEncryptionKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := hex.DecodeString(s); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("%w: %s", ErrEncryptionKey, T("encrypt.key_format"))
+	}
+	return key, nil
+}
+
+// CipherFromEnv membuat Cipher dari TABLEQA_ENCRYPTION_KEY atau file di TABLEQA_ENCRYPTION_KEY_FILE.
+// Mengembalikan nil tanpa error jika keduanya kosong, artinya enkripsi tidak aktif.
+func CipherFromEnv() (*Cipher, error) {
+	value := os.Getenv(EncryptionKeyEnv)
+	if path := os.Getenv(EncryptionKeyFileEnv); value == "" && path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionKey, err)
+		}
+		value = string(data)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	key, err := ParseEncryptionKey(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(key)
+}
+
+// Seal mengenkripsi plaintext; hasilnya nonce diikuti ciphertext
+func (c *Cipher) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open membuka data hasil Seal
+func (c *Cipher) Open(data []byte) ([]byte, error) {
+	if len(data) < c.aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// storageCipher Cipher untuk semua file yang ditulis aplikasi, nil berarti tanpa enkripsi
+var storageCipher *Cipher
+
+// SetStorageCipher mengaktifkan enkripsi at rest untuk history, label, CSV, dan laporan.
+// File lama yang belum terenkripsi tetap bisa dibaca sehingga bisa dimigrasi bertahap.
+func SetStorageCipher(c *Cipher) {
+	storageCipher = c
+}
+
+// sealLine mengenkripsi satu baris JSONL (tanpa newline) jika enkripsi aktif
+func sealLine(data []byte) ([]byte, error) {
+	if storageCipher == nil {
+		return data, nil
+	}
+	sealed, err := storageCipher.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(encryptedLinePrefix + base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// openLine membuka satu baris JSONL; baris plaintext dikembalikan apa adanya
+func openLine(line []byte) ([]byte, error) {
+	if !bytes.HasPrefix(line, []byte(encryptedLinePrefix)) {
+		return line, nil
+	}
+	if storageCipher == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEncryptionKey, T("encrypt.key_required", EncryptionKeyEnv, EncryptionKeyFileEnv))
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(line[len(encryptedLinePrefix):])))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return storageCipher.Open(sealed)
+}
+
+// ReadDataFile membaca file yang mungkin terenkripsi utuh; file plaintext dikembalikan apa adanya
+func ReadDataFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(data, []byte(encryptedFileMagic)) {
+		return data, err
+	}
+	if storageCipher == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEncryptionKey, T("encrypt.file_encrypted", path, EncryptionKeyEnv, EncryptionKeyFileEnv))
+	}
+	plaintext, err := storageCipher.Open(data[len(encryptedFileMagic):])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return plaintext, nil
+}
+
+// WriteDataFile menulis file, terenkripsi utuh jika enkripsi aktif
+func WriteDataFile(path string, data []byte, perm os.FileMode) error {
+	if storageCipher != nil {
+		sealed, err := storageCipher.Seal(data)
+		if err != nil {
+			return err
+		}
+		data = append([]byte(encryptedFileMagic), sealed...)
+	}
+	return ioutil.WriteFile(path, data, perm)
+}
+
+// sealedLineWriter io.Writer yang mengenkripsi setiap baris lengkap sebelum ditulis ke w
+type sealedLineWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// newDataLineWriter membungkus w agar baris JSONL terenkripsi jika enkripsi aktif
+func newDataLineWriter(w io.Writer) io.Writer {
+	if storageCipher == nil {
+		return w
+	}
+	return &sealedLineWriter{w: w}
+}
+
+func (s *sealedLineWriter) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line, err := sealLine(s.buf[:i])
+		if err != nil {
+			return 0, err
+		}
+		if _, err := s.w.Write(append(line, '\n')); err != nil {
+			return 0, err
+		}
+		s.buf = s.buf[i+1:]
+	}
+}
+
+// EncryptFile mengenkripsi file yang sudah ada di tempat: file .jsonl per baris agar tetap bisa
+// ditambah, file lain utuh. Baris atau file yang sudah terenkripsi tidak dienkripsi dua kali.
+func EncryptFile(path string) error {
+	data, err := ReadDataFile(path)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(path) != ".jsonl" {
+		return writeFileAtomic(path, data, WriteDataFile)
+	}
+	var out bytes.Buffer
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		plain, err := openLine(line)
+		if err != nil {
+			return err
+		}
+		sealed, err := sealLine(plain)
+		if err != nil {
+			return err
+		}
+		out.Write(append(sealed, '\n'))
+	}
+	return writeFileAtomic(path, out.Bytes(), func(path string, data []byte, perm os.FileMode) error {
+		return ioutil.WriteFile(path, data, perm)
+	})
+}
+
+// writeFileAtomic menulis ke file sementara lalu rename agar file asli tidak rusak jika gagal
+func writeFileAtomic(path string, data []byte, write func(string, []byte, os.FileMode) error) error {
+	tmp := path + ".tmp"
+	if err := write(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// runEncrypt menjalankan perintah "encrypt": go run . encrypt history.jsonl labels.jsonl data-series.csv
+// atau go run . encrypt -keygen untuk membuat kunci baru
+func runEncrypt(args []string) error {
+	if len(args) == 1 && args[0] == "-keygen" {
+		key, err := GenerateEncryptionKey()
+		if err != nil {
+			return err
+		}
+		fmt.Println(key)
+		return nil
+	}
+	if storageCipher == nil {
+		return fmt.Errorf("%w: %s", ErrEncryptionKey, T("encrypt.set_key", EncryptionKeyEnv, EncryptionKeyFileEnv))
+	}
+	for _, path := range args {
+		if err := EncryptFile(path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package main_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption at rest", func() {
+	var (
+		dir    string
+		cipher *main.Cipher
+	)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		var err error
+		cipher, err = main.NewCipher(key)
+		Expect(err).ShouldNot(HaveOccurred())
+		main.SetStorageCipher(cipher)
+	})
+
+	AfterEach(func() {
+		main.SetStorageCipher(nil)
+	})
+
+	It("reads keys from the environment as base64 or hex", func() {
+		generated, err := main.GenerateEncryptionKey()
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = main.ParseEncryptionKey(generated)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(main.ParseEncryptionKey(hex.EncodeToString(key))).Should(Equal(key))
+		_, err = main.ParseEncryptionKey("too-short")
+		Expect(err).Should(MatchError(main.ErrEncryptionKey))
+
+		Expect(main.CipherFromEnv()).Should(BeNil())
+		keyFile := filepath.Join(dir, "key")
+		Expect(ioutil.WriteFile(keyFile, []byte(generated+"\n"), 0o600)).Should(Succeed())
+		os.Setenv(main.EncryptionKeyFileEnv, keyFile)
+		defer os.Unsetenv(main.EncryptionKeyFileEnv)
+		Expect(main.CipherFromEnv()).ShouldNot(BeNil())
+	})
+
+	It("encrypts history lines and reads them back", func() {
+		path := filepath.Join(dir, "history.jsonl")
+		history, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		id, err := history.Record(main.HistoryEntry{Question: "How much energy did the kitchen use?", Backend: "fake"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(history.SetFeedback(id, main.Feedback{Correct: true})).Should(Succeed())
+		Expect(history.Close()).Should(Succeed())
+
+		data, err := ioutil.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).ShouldNot(ContainSubstring("kitchen"))
+		Expect(strings.Split(strings.TrimSpace(string(data)), "\n")).Should(HaveEach(HavePrefix("enc1:")))
+
+		history, err = main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		entry, err := history.Get(id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entry.Question).Should(ContainSubstring("kitchen"))
+		Expect(entry.Feedback.Correct).Should(BeTrue())
+		history.Close()
+
+		main.SetStorageCipher(nil)
+		_, err = main.OpenHistory(path, main.Retention{})
+		Expect(err).Should(MatchError(main.ErrEncryptionKey))
+		other, _ := main.NewCipher([]byte("fedcba9876543210fedcba9876543210"))
+		main.SetStorageCipher(other)
+		_, err = main.OpenHistory(path, main.Retention{})
+		Expect(err).Should(MatchError(main.ErrDecrypt))
+	})
+
+	It("migrates plaintext history when it is opened with a key", func() {
+		path := filepath.Join(dir, "history.jsonl")
+		plain := `{"entry":{"id":"1-1","question":"Which room used the most energy?","backend":"fake","response":{"answer":"Kitchen","coordinates":null,"cells":null,"aggregator":""}}}` + "\n"
+		Expect(ioutil.WriteFile(path, []byte(plain), 0o600)).Should(Succeed())
+
+		history, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(history.Search(main.HistoryQuery{Text: "room"})).Should(HaveLen(1))
+		history.Close()
+		data, _ := ioutil.ReadFile(path)
+		Expect(string(data)).Should(HavePrefix("enc1:"))
+	})
+
+	It("encrypts labels and whole files", func() {
+		labels := filepath.Join(dir, "labels.jsonl")
+		store := &main.LabelStore{Path: labels}
+		Expect(store.Add(main.LabeledExample{Question: "How much energy did the TV use?", Expected: "0.5"})).Should(Succeed())
+		// Ciphertext base64 bisa kebetulan berisi "TV", jadi yang dicek potongan teks yang lebih panjang
+		data, _ := ioutil.ReadFile(labels)
+		Expect(string(data)).ShouldNot(ContainSubstring("How much energy"))
+		examples, err := main.LoadLabels(labels)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(examples[0].Expected).Should(Equal("0.5"))
+
+		csv := filepath.Join(dir, "home.csv")
+		Expect(ioutil.WriteFile(csv, []byte("Appliance,Energy_Consumption\nTV,0.5\n"), 0o644)).Should(Succeed())
+		Expect(main.ReadDataFile(csv)).Should(ContainSubstring("TV"))
+		Expect(main.EncryptFile(csv)).Should(Succeed())
+		data, _ = ioutil.ReadFile(csv)
+		Expect(string(data)).ShouldNot(ContainSubstring("Energy_Consumption"))
+		Expect(main.ReadDataFile(csv)).Should(Equal([]byte("Appliance,Energy_Consumption\nTV,0.5\n")))
+
+		// Mengenkripsi ulang tidak mengubah isi yang bisa dibaca
+		Expect(main.EncryptFile(csv)).Should(Succeed())
+		Expect(main.EncryptFile(labels)).Should(Succeed())
+		Expect(main.ReadDataFile(csv)).Should(ContainSubstring("TV"))
+		Expect(main.LoadLabels(labels)).Should(HaveLen(1))
+
+		main.SetStorageCipher(nil)
+		_, err = main.ReadDataFile(csv)
+		Expect(err).Should(MatchError(main.ErrEncryptionKey))
+	})
+})
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// IntentScore struct akurasi untuk satu jenis pertanyaan
+type IntentScore struct {
+	Intent   string  `json:"intent"`
+	Total    int     `json:"total"`
+	Correct  int     `json:"correct"`
+	Errors   int     `json:"errors"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// EvalCase struct hasil satu contoh berlabel
+type EvalCase struct {
+	Question string `json:"question"`
+	Intent   string `json:"intent"`
+	Expected string `json:"expected"`
+	Got      string `json:"got"`
+	Correct  bool   `json:"correct"`
+	Error    string `json:"error,omitempty"`
+}
+
+// EvalReport struct ringkasan evaluasi sebuah backend
+type EvalReport struct {
+	Backend  string        `json:"backend"`
+	Total    int           `json:"total"`
+	Correct  int           `json:"correct"`
+	Accuracy float64       `json:"accuracy"`
+	Intents  []IntentScore `json:"intents"`
+	Cases    []EvalCase    `json:"cases"`
+}
+
+// Evaluate memutar ulang semua contoh berlabel ke pipeline dan menghitung akurasi per intent. Setiap
+// contoh ditanyakan ke tabel dataset-nya di tables (DatasetID kosong berarti DefaultDatasetID);
+// contoh yang tabelnya tidak ada dihitung sebagai error, bukan ditanyakan ke tabel lain.
+func Evaluate(ctx context.Context, pipeline *Pipeline, tables map[string]map[string][]string, examples []LabeledExample) EvalReport {
+	report := EvalReport{Backend: pipeline.Backend.Name()}
+	// Evaluasi tidak ditunggu user, jadi didahului pertanyaan interaktif di dispatcher
+	ctx = WithPriority(ctx, PriorityBatch)
+	scores := make(map[string]*IntentScore)
+
+	for _, ex := range examples {
+		// Saat shutdown laporan berisi contoh yang sudah dijalankan saja
+		if ctx.Err() != nil {
+			break
+		}
+		intent := ex.Intent
+		if intent == "" {
+			intent = ClassifyIntent(ex.Question)
+		}
+		score, ok := scores[intent]
+		if !ok {
+			score = &IntentScore{Intent: intent}
+			scores[intent] = score
+		}
+
+		c := EvalCase{Question: ex.Question, Intent: intent, Expected: ex.Expected}
+		datasetID := ex.DatasetID
+		if datasetID == "" {
+			datasetID = DefaultDatasetID
+		}
+		table, ok := tables[datasetID]
+		var result Result
+		var err error
+		if ok {
+			// Filter yang dipakai saat pertanyaan asli dijawab ikut diputar ulang agar skornya sebanding
+			result, err = pipeline.Ask(ctx, Question{DatasetID: datasetID, Table: table, Query: ex.Question, Filter: ex.Filter})
+		} else {
+			err = fmt.Errorf("%w: %s", ErrDatasetNotFound, T("eval.dataset_missing", datasetID, datasetID))
+		}
+		if err != nil {
+			c.Error = err.Error()
+			score.Errors++
+		} else {
+			got, ok := ComputeAnswer(result.Response)
+			if !ok {
+				got = result.Response.Answer
+			}
+			c.Got = got
+			c.Correct = AnswersMatch(got, ex.Expected)
+		}
+
+		score.Total++
+		report.Total++
+		if c.Correct {
+			score.Correct++
+			report.Correct++
+		}
+		report.Cases = append(report.Cases, c)
+	}
+
+	for _, score := range scores {
+		score.Accuracy = float64(score.Correct) / float64(score.Total)
+		report.Intents = append(report.Intents, *score)
+	}
+	sort.Slice(report.Intents, func(i, j int) bool { return report.Intents[i].Intent < report.Intents[j].Intent })
+	if report.Total > 0 {
+		report.Accuracy = float64(report.Correct) / float64(report.Total)
+	}
+	return report
+}
+
+// PrintEvalReport menampilkan ringkasan evaluasi dalam bentuk tabel
+func PrintEvalReport(out io.Writer, report EvalReport) {
+	fmt.Fprintln(out, T("eval.backend", report.Backend))
+	fmt.Fprintf(out, "%-10s %6s %8s %7s %9s\n", T("eval.intent"), T("eval.total"), T("eval.correct"), T("eval.errors"), T("eval.accuracy"))
+	for _, s := range report.Intents {
+		fmt.Fprintf(out, "%-10s %6d %8d %7d %8.1f%%\n", s.Intent, s.Total, s.Correct, s.Errors, s.Accuracy*100)
+	}
+	fmt.Fprintf(out, "%-10s %6d %8d %7s %8.1f%%\n", T("eval.overall"), report.Total, report.Correct, "", report.Accuracy*100)
+}
+
+// runEval menjalankan perintah "eval": go run . eval -labels labels.jsonl -model google/tapas-large-finetuned-wtq
+func runEval(ctx context.Context, args []string, cfg Config) error {
+	fs := flag.NewFlagSet("eval", flag.ExitOnError)
+	labelsFile := fs.String("labels", cfg.Labels, "labeled examples to replay (default: the profile's labels)")
+	csvFile := fs.String("csv", cfg.CSV, "table of the default dataset (default: the profile's csv)")
+	var datasets stringList
+	fs.Var(&datasets, "dataset", "table for examples labeled against a server dataset, e.g. \"kos=kos.csv\" (repeatable)")
+	model := fs.String("model", "", "Huggingface model to evaluate instead of the configured backend")
+	outFile := fs.String("out", "", "write the full report as JSON to this file")
+	fs.Parse(args)
+
+	examples, err := LoadLabels(*labelsFile)
+	if err != nil {
+		return err
+	}
+	tables := make(map[string]map[string][]string)
+	if tables[DefaultDatasetID], err = readCSVTable(*csvFile); err != nil {
+		return err
+	}
+	for _, spec := range datasets {
+		id, path, ok := strings.Cut(spec, "=")
+		id, path = strings.TrimSpace(id), strings.TrimSpace(path)
+		if !ok || id == "" || path == "" {
+			return errors.New(T("eval.dataset_syntax", spec))
+		}
+		if tables[id], err = readCSVTable(path); err != nil {
+			return err
+		}
+	}
+
+	// Backend dan pipeline dibangun sama seperti REPL sehingga skor eval berlaku untuk backend yang
+	// benar-benar dipakai, termasuk ONNX, fake server, model cadangan, dan mode privasi
+	if *model != "" {
+		cfg.Model.URL = ModelURL(*model)
+	}
+	dispatcher, err := NewDispatcherFromConfig(cfg.Server)
+	if err != nil {
+		return err
+	}
+	backend, closeBackend, err := BuildBackend(cfg, dispatcher)
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+	pipeline := &Pipeline{Backend: backend, Normalize: cfg.Normalize, PreAggregate: cfg.PreAggregate, MaxTokens: cfg.MaxTokens}
+	// Pertanyaan berbahasa Indonesia diterjemahkan seperti trafik asli agar skornya sebanding
+	if pipeline.Translator, err = BuildTranslator(cfg); err != nil {
+		return err
+	}
+	report := Evaluate(ctx, pipeline, tables, examples)
+	PrintEvalReport(os.Stdout, report)
+
+	if *outFile != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		return WriteDataFile(*outFile, data, 0o644)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main_test
+
+import (
+	"context"
+	"path/filepath"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feedback and evaluation", func() {
+	It("computes answers from cells and aggregator", func() {
+		answer, ok := main.ComputeAnswer(main.Response{Cells: []string{"1.2", "3.6"}, Aggregator: "AVERAGE"})
+		Expect(ok).Should(BeTrue())
+		Expect(answer).Should(Equal("2.4"))
+
+		Expect(main.AnswersMatch("SUM > 4.8", "4.80")).Should(BeTrue())
+		Expect(main.AnswersMatch("Kitchen", "kitchen")).Should(BeTrue())
+		Expect(main.AnswersMatch("4.8", "5")).Should(BeFalse())
+	})
+
+	It("classifies question intents", func() {
+		Expect(main.ClassifyIntent("What is the average power consumption?")).Should(Equal(main.IntentAverage))
+		Expect(main.ClassifyIntent("Compare the energy consumption between June 2023 and June 2024.")).Should(Equal(main.IntentCompare))
+		Expect(main.ClassifyIntent("When was the highest energy consumption recorded?")).Should(Equal(main.IntentMax))
+		Expect(main.ClassifyIntent("Which room has the fridge?")).Should(Equal(main.IntentLookup))
+	})
+
+	It("turns feedback into labeled examples and replays them", func() {
+		dir := GinkgoT().TempDir()
+		history, err := main.OpenHistory(filepath.Join(dir, "history.jsonl"), main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer history.Close()
+		labels := &main.LabelStore{Path: filepath.Join(dir, "labels.jsonl")}
+
+		backend := &fakeBackend{response: main.Response{Answer: "SUM > 1.2, 1.2", Cells: []string{"1.2", "1.2"}, Aggregator: "SUM"}}
+		pipeline := &main.Pipeline{Backend: backend, History: history, Labels: labels}
+		table := map[string][]string{"Energy_Consumption": {"1.2", "1.2"}}
+
+		first, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = pipeline.RecordFeedback("", first.ID, true, "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		second, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the average energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = pipeline.RecordFeedback("", second.ID, false, "1.2")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		examples, err := main.LoadLabels(labels.Path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(examples).Should(HaveLen(2))
+		Expect(examples[0].Expected).Should(Equal("2.4"))
+
+		report := main.Evaluate(context.Background(), &main.Pipeline{Backend: backend}, map[string]map[string][]string{main.DefaultDatasetID: table}, examples)
+		Expect(report.Total).Should(Equal(2))
+		Expect(report.Correct).Should(Equal(1))
+		Expect(report.Intents).Should(Equal([]main.IntentScore{
+			{Intent: main.IntentAverage, Total: 1, Correct: 0, Accuracy: 0},
+			{Intent: main.IntentSum, Total: 1, Correct: 1, Accuracy: 1},
+		}))
+	})
+
+	It("replays each labeled example with the filter it was asked with", func() {
+		dir := GinkgoT().TempDir()
+		history, err := main.OpenHistory(filepath.Join(dir, "history.jsonl"), main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer history.Close()
+		labels := &main.LabelStore{Path: filepath.Join(dir, "labels.jsonl")}
+
+		backend := &fakeBackend{response: main.Response{Answer: "1.2", Cells: []string{"1.2"}, Aggregator: "NONE"}}
+		pipeline := &main.Pipeline{Backend: backend, History: history, Labels: labels}
+		table := map[string][]string{"Room": {"Kitchen", "Living Room"}, "Energy_Consumption": {"1.2", "0.5"}}
+
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How much energy?", Filter: `Room = "Kitchen"`})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(history.Get(result.ID)).Should(HaveField("Filter", `Room = "Kitchen"`))
+		_, err = pipeline.RecordFeedback("", result.ID, true, "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		examples, err := main.LoadLabels(labels.Path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(examples).Should(HaveLen(1))
+		Expect(examples[0].Filter).Should(Equal(`Room = "Kitchen"`))
+
+		replay := &fakeBackend{response: backend.response}
+		report := main.Evaluate(context.Background(), &main.Pipeline{Backend: replay}, map[string]map[string][]string{main.DefaultDatasetID: table}, examples)
+		Expect(report.Correct).Should(Equal(1))
+		Expect(replay.asked).Should(HaveLen(1))
+		Expect(replay.asked[0].Table["Room"]).Should(Equal([]string{"Kitchen"}))
+	})
+	It("replays examples against their own dataset with the configured translator", func() {
+		home := map[string][]string{"Energy_Consumption": {"1.2"}}
+		kos := map[string][]string{"Energy_Consumption": {"0.4", "0.6"}}
+		examples := []main.LabeledExample{
+			{Question: "Berapa total konsumsi energi?", Expected: "1", DatasetID: "kos"},
+			{Question: "What is the total energy?", Expected: "1.2"},
+			{Question: "What is the total energy?", Expected: "1", DatasetID: "gone"},
+		}
+		translator, err := main.BuildTranslator(main.Config{Translator: "lexicon"})
+		Expect(err).ShouldNot(HaveOccurred())
+		replay := &fakeBackend{response: main.Response{Answer: "1", Aggregator: "NONE"}}
+		report := main.Evaluate(context.Background(), &main.Pipeline{Backend: replay, Translator: translator},
+			map[string]map[string][]string{main.DefaultDatasetID: home, "kos": kos}, examples)
+
+		Expect(replay.asked).Should(HaveLen(2))
+		Expect(replay.asked[0].Table).Should(Equal(kos))
+		Expect(replay.asked[0].Query).ShouldNot(ContainSubstring("Berapa"))
+		Expect(replay.asked[1].Table).Should(Equal(home))
+		// Dataset yang tidak diberikan tidak diganti tabel lain
+		Expect(report.Cases[2].Error).Should(ContainSubstring("gone"))
+	})
+
+	It("does not label questions that failed", func() {
+		dir := GinkgoT().TempDir()
+		history, err := main.OpenHistory(filepath.Join(dir, "history.jsonl"), main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer history.Close()
+		pipeline := &main.Pipeline{Backend: &fakeBackend{}, History: history}
+
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: map[string][]string{"Room": {"Kitchen"}}, Query: "Which room?", Filter: `Room = "Garage"`})
+		Expect(err).Should(MatchError(main.ErrFilterNoRows))
+		_, err = pipeline.RecordFeedback("", result.ID, true, "")
+		Expect(err).Should(MatchError(main.ErrFeedbackOnError))
+	})
+})
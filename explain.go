@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CellProvenance struct satu cell yang dipilih model beserta asal barisnya di tabel asli
+type CellProvenance struct {
+	// Row indeks baris di tabel yang dikirim, SourceRow indeks baris di tabel asli
+	Row       int    `json:"row"`
+	SourceRow int    `json:"source_row"`
+	Column    string `json:"column"`
+	Value     string `json:"value"`
+	// Reported nilai cell menurut model, bisa berbeda jika model salah membaca tabel
+	Reported string `json:"reported,omitempty"`
+}
+
+// Explanation struct jejak bagaimana sebuah jawaban diperoleh, dari pertanyaan sampai hitungan ulang lokal
+type Explanation struct {
+	Original string `json:"original"`
+	Query    string `json:"query"`
+	Language string `json:"language"`
+	Intent   string `json:"intent"`
+	// Corrections koreksi ejaan dan angka dari normalisasi pertanyaan
+	Corrections []Correction `json:"corrections,omitempty"`
+	// Filters filter yang diterapkan sebelum tabel dikirim ke model
+	Filters   []string `json:"filters,omitempty"`
+	RowsTotal int      `json:"rows_total"`
+	RowsSent  int      `json:"rows_sent"`
+	// SentRows rentang baris asli yang dikirim, misalnya "120-143"; kosong berarti semua baris
+	SentRows []string `json:"sent_rows,omitempty"`
+	// PreAggregation ringkasan yang dikirim menggantikan baris asli; SourceRow cell menjadi -1
+	PreAggregation string `json:"pre_aggregation,omitempty"`
+	// Tokens perkiraan token request, hanya ada jika batas token diperiksa
+	Tokens      *TokenEstimate   `json:"tokens,omitempty"`
+	Aggregator  string           `json:"aggregator"`
+	Cells       []CellProvenance `json:"cells"`
+	ModelAnswer string           `json:"model_answer"`
+	// Recomputed hasil ComputeAnswer dari cell yang dipilih, kosong jika tidak bisa dihitung
+	Recomputed string `json:"recomputed,omitempty"`
+	// CellsMatch true jika semua cell yang dilaporkan model sama dengan isi tabel
+	CellsMatch bool `json:"cells_match"`
+	// Confidence skor keyakinan model, nil jika model tidak mengembalikan probabilitas
+	Confidence *float64       `json:"confidence,omitempty"`
+	Route      []RouteAttempt `json:"route,omitempty"`
+}
+
+// validCoordinate true jika koordinat dari model berbentuk [baris, kolom] tanpa indeks negatif.
+// Koordinat lain dilewati, sama seperti di unmaskResponse.
+func validCoordinate(c []int) bool {
+	return len(c) == 2 && c[0] >= 0 && c[1] >= 0
+}
+
+// explain menyusun Explanation dari hasil pertanyaan. sent tabel yang dikirim ke model,
+// rows indeks baris asli untuk setiap baris di sent (nil berarti tidak difilter).
+func explain(result Result, original, sent map[string][]string, rows []int) Explanation {
+	resp := result.Response
+	e := Explanation{
+		Original:       result.Original,
+		Query:          result.Query,
+		Language:       result.Language,
+		Intent:         ClassifyIntent(result.Query),
+		Corrections:    result.Corrections,
+		Filters:        result.Filters,
+		RowsTotal:      tableRows(original),
+		RowsSent:       tableRows(sent),
+		SentRows:       rowRanges(rows),
+		PreAggregation: result.PreAggregation,
+		Tokens:         result.Tokens,
+		Aggregator:     resp.Aggregator,
+		Cells:          make([]CellProvenance, 0, len(resp.Coordinates)),
+		ModelAnswer:    resp.Answer,
+		CellsMatch:     len(resp.Cells) == len(resp.Coordinates),
+	}
+	if value, ok := ComputeAnswer(resp); ok {
+		e.Recomputed = value
+	}
+	if confidence, ok := resp.Confidence(); ok {
+		e.Confidence = &confidence
+	}
+	e.Route = result.Route
+
+	columns := sortedColumns(sent)
+	for i, coord := range resp.Coordinates {
+		if !validCoordinate(coord) {
+			e.CellsMatch = false
+			continue
+		}
+		cell := CellProvenance{Row: coord[0], SourceRow: coord[0]}
+		if result.PreAggregation != "" {
+			cell.SourceRow = -1
+		} else if rows != nil && coord[0] < len(rows) {
+			cell.SourceRow = rows[coord[0]]
+		}
+		if coord[1] < len(columns) {
+			cell.Column = columns[coord[1]]
+			if values := sent[cell.Column]; coord[0] < len(values) {
+				cell.Value = values[coord[0]]
+			}
+		}
+		if i < len(resp.Cells) {
+			cell.Reported = resp.Cells[i]
+		}
+		if strings.TrimSpace(cell.Reported) != strings.TrimSpace(cell.Value) {
+			e.CellsMatch = false
+		}
+		e.Cells = append(e.Cells, cell)
+	}
+	return e
+}
+
+// rowRanges meringkas indeks baris urut menjadi rentang seperti "0-23", "48"
+func rowRanges(rows []int) []string {
+	var ranges []string
+	for i := 0; i < len(rows); {
+		j := i
+		for j+1 < len(rows) && rows[j+1] == rows[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, fmt.Sprint(rows[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", rows[i], rows[j]))
+		}
+		i = j + 1
+	}
+	return ranges
+}
+
+// PrintExplanation menulis Explanation dalam bahasa lang untuk REPL
+func PrintExplanation(out io.Writer, e Explanation, lang string) {
+	fmt.Fprintln(out, Tl(lang, "explain.question", e.Original))
+	if e.Query != e.Original {
+		fmt.Fprintln(out, Tl(lang, "explain.translated", e.Query))
+	}
+	for _, c := range e.Corrections {
+		fmt.Fprintln(out, Tl(lang, "explain.correction", c.From, c.To))
+	}
+	fmt.Fprintln(out, Tl(lang, "explain.intent", e.Intent))
+	if len(e.Filters) == 0 {
+		fmt.Fprintln(out, Tl(lang, "explain.no_filter"))
+	}
+	for _, f := range e.Filters {
+		fmt.Fprintln(out, Tl(lang, "explain.filter", f))
+	}
+	sent := strings.Join(e.SentRows, ", ")
+	if e.PreAggregation != "" {
+		sent = Tl(lang, "explain.pre_aggregated", e.PreAggregation)
+	} else if sent == "" {
+		sent = Tl(lang, "explain.all_rows")
+	}
+	fmt.Fprintln(out, Tl(lang, "explain.rows", e.RowsSent, e.RowsTotal, sent))
+	if e.Tokens != nil {
+		fmt.Fprintln(out, Tl(lang, "explain.tokens", e.Tokens.Total, e.Tokens.Limit))
+	}
+	fmt.Fprintln(out, Tl(lang, "explain.aggregator", e.Aggregator))
+	for _, c := range e.Cells {
+		if c.SourceRow < 0 {
+			fmt.Fprintln(out, Tl(lang, "explain.summary_cell", c.Row, c.Column, c.Value))
+			continue
+		}
+		fmt.Fprintln(out, Tl(lang, "explain.cell", c.SourceRow, c.Column, c.Value))
+	}
+	fmt.Fprintln(out, Tl(lang, "explain.model_answer", e.ModelAnswer))
+	if e.Recomputed != "" {
+		fmt.Fprintln(out, Tl(lang, "explain.recomputed", LocalizeText(e.Recomputed, lang)))
+	}
+	if !e.CellsMatch {
+		fmt.Fprintln(out, Tl(lang, "explain.mismatch"))
+	}
+	if e.Confidence != nil {
+		fmt.Fprintln(out, Tl(lang, "explain.confidence", *e.Confidence))
+	}
+	for _, r := range e.Route {
+		fmt.Fprintln(out, Tl(lang, "explain.route", r.Backend, r.Confidence, r.Accepted, r.Error))
+	}
+}
@@ -0,0 +1,76 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explanation", func() {
+	table := map[string][]string{
+		"Appliance":          {"TV", "Fridge", "Heater", "Fridge"},
+		"Date":               {"2023-05-31", "2023-06-01", "2023-06-02", "2023-07-01"},
+		"Energy_Consumption": {"0.5", "1.2", "2.3", "0.9"},
+	}
+
+	It("traces filtered rows, chosen cells and the local recomputation", func() {
+		backend := &fakeBackend{response: main.Response{
+			Answer:      "SUM > 1.2, 2.3",
+			Coordinates: [][]int{{0, 2}, {1, 2}},
+			Cells:       []string{"1.2", "2.3"},
+			Aggregator:  "SUM",
+		}}
+		pipeline := &main.Pipeline{Backend: backend}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy consumption in June 2023?"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		e := result.Explanation
+		Expect(e.RowsTotal).Should(Equal(4))
+		Expect(e.RowsSent).Should(Equal(2))
+		Expect(e.SentRows).Should(Equal([]string{"1-2"}))
+		Expect(e.Filters).Should(HaveLen(1))
+		Expect(e.Aggregator).Should(Equal("SUM"))
+		Expect(e.Cells).Should(Equal([]main.CellProvenance{
+			{Row: 0, SourceRow: 1, Column: "Energy_Consumption", Value: "1.2", Reported: "1.2"},
+			{Row: 1, SourceRow: 2, Column: "Energy_Consumption", Value: "2.3", Reported: "2.3"},
+		}))
+		Expect(e.Recomputed).Should(Equal("3.5"))
+		Expect(e.CellsMatch).Should(BeTrue())
+
+		var out bytes.Buffer
+		main.PrintExplanation(&out, e, main.LangEnglish)
+		Expect(out.String()).Should(ContainSubstring("Rows sent: 2 of 4 (1-2)"))
+		Expect(out.String()).Should(ContainSubstring("Recomputed locally: 3.5"))
+	})
+
+	It("flags cells the model misreported", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "7", Coordinates: [][]int{{0, 2}}, Cells: []string{"7"}, Aggregator: "NONE"}}
+		pipeline := &main.Pipeline{Backend: backend}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Explanation.SentRows).Should(BeEmpty())
+		Expect(result.Explanation.CellsMatch).Should(BeFalse())
+	})
+
+	It("skips malformed coordinates from the model", func() {
+		backend := &fakeBackend{response: main.Response{
+			Answer:      "SUM > 2.3",
+			Coordinates: [][]int{{0}, {-1, 2}, {1, 2}, {0, 2, 1}},
+			Cells:       []string{"1.2", "0.5", "2.3", "1.2"},
+			Aggregator:  "SUM",
+		}}
+		pipeline := &main.Pipeline{Backend: backend}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy consumption in June 2023?"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(result.Explanation.Cells).Should(Equal([]main.CellProvenance{
+			{Row: 1, SourceRow: 2, Column: "Energy_Consumption", Value: "2.3", Reported: "2.3"},
+		}))
+		Expect(result.Explanation.CellsMatch).Should(BeFalse())
+		Expect(result.SourceCoordinates()).Should(Equal([][]int{{2, 2}}))
+	})
+})
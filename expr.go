@@ -0,0 +1,955 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Bahasa ekspresi kecil untuk kolom turunan, misalnya
+//
+//	Cost = Energy_Consumption * 1444.7
+//	IsPeak = hour(Time) between 17 and 22
+//
+// Ekspresi hanya bisa membaca kolom di baris yang sama dan memanggil fungsi bawaan,
+// tidak ada variabel, perulangan, atau akses ke luar tabel.
+
+// Kind tipe nilai di ekspresi
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindNumber
+	KindString
+	KindBool
+	KindDate
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindBool:
+		return "bool"
+	case KindDate:
+		return "date"
+	}
+	return "null"
+}
+
+// Value struct satu nilai hasil evaluasi; cell kosong menjadi KindNull.
+// Tanggal disimpan di Str dalam format 2006-01-02 sehingga bisa dibandingkan sebagai teks.
+type Value struct {
+	Kind Kind
+	Num  float64
+	Str  string
+	Bool bool
+}
+
+// String menampilkan nilai seperti yang disimpan di tabel
+func (v Value) String() string {
+	switch v.Kind {
+	case KindNumber:
+		return formatNumber(v.Num)
+	case KindString, KindDate:
+		return v.Str
+	case KindBool:
+		return strconv.FormatBool(v.Bool)
+	}
+	return ""
+}
+
+// cellValue mengubah isi cell menjadi Value: angka atau tanggal jika bisa di-parse, selain itu string
+func cellValue(cell string) Value {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return Value{}
+	}
+	if n, err := strconv.ParseFloat(cell, 64); err == nil {
+		return Value{Kind: KindNumber, Num: n}
+	}
+	if _, err := time.Parse(dateLayout, cell); err == nil {
+		return Value{Kind: KindDate, Str: cell}
+	}
+	return Value{Kind: KindString, Str: cell}
+}
+
+// Row fungsi untuk membaca isi kolom pada baris yang sedang dievaluasi
+type Row func(column string) Value
+
+// Expr node ekspresi yang sudah di-parse
+type Expr interface {
+	Eval(row Row) (Value, error)
+	String() string
+}
+
+const (
+	maxExprLength = 1024
+	maxExprDepth  = 64
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokDate
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lexExpr(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isDateLiteral(runes[i:]):
+			// Tanggal boleh ditulis tanpa tanda kutip: Date >= 2022-01-01
+			tokens = append(tokens, token{tokDate, string(runes[i : i+len(dateLayout)]), i})
+			i += len(dateLayout)
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, string(runes[start:i]), start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[start:i]), start})
+		case r == '`':
+			// Nama kolom dengan spasi ditulis di antara backtick: `Energy Consumption`
+			end := indexRune(runes, '`', i+1)
+			if end < 0 {
+				return nil, errors.New(T("expr.unterminated_column", i))
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[i+1 : end]), i})
+			i = end + 1
+		case r == '\'' || r == '"':
+			end := indexRune(runes, r, i+1)
+			if end < 0 {
+				return nil, errors.New(T("expr.unterminated_string", i))
+			}
+			tokens = append(tokens, token{tokString, string(runes[i+1 : end]), i})
+			i = end + 1
+		default:
+			op := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<=", ">=", "<>":
+					op = two
+				}
+			}
+			if !strings.Contains("+-*/%()<>=!,", op[:1]) {
+				return nil, errors.New(T("expr.unexpected", r, i))
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len([]rune(op))
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// isDateLiteral mengecek apakah runes diawali tanggal YYYY-MM-DD yang tidak diikuti angka lain
+func isDateLiteral(runes []rune) bool {
+	if len(runes) < len(dateLayout) {
+		return false
+	}
+	for i, r := range runes[:len(dateLayout)] {
+		if i == 4 || i == 7 {
+			if r != '-' {
+				return false
+			}
+		} else if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return len(runes) == len(dateLayout) || !unicode.IsDigit(runes[len(dateLayout)]) && runes[len(dateLayout)] != '.'
+}
+
+func indexRune(runes []rune, r rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// ParseExpr mem-parse satu ekspresi
+func ParseExpr(src string) (Expr, error) {
+	if len(src) > maxExprLength {
+		return nil, errors.New(T("expr.too_long", maxExprLength))
+	}
+	tokens, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errors.New(T("expr.unexpected", t.text, t.pos))
+	}
+	return e, nil
+}
+
+func (p *exprParser) peek() token { return p.tokens[p.pos] }
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword mengecek kata kunci seperti "and" tanpa membedakan huruf besar-kecil
+func (p *exprParser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokIdent && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) op(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(op string) error {
+	if _, ok := p.op(op); !ok {
+		t := p.peek()
+		return errors.New(T("expr.expected", op, t.pos))
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExprDepth {
+		return nil, errors.New(T("expr.too_deep", maxExprDepth))
+	}
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (Expr, error) {
+	if p.keyword("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.keyword("between") {
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("and") {
+			return nil, errors.New(T("expr.between_and", p.peek().pos))
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{value: left, low: low, high: high}, nil
+	}
+	if op, ok := p.op("==", "=", "!=", "<>", "<", "<=", ">", ">="); ok {
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "=":
+			op = "=="
+		case "<>":
+			op = "!="
+		}
+		return &binaryExpr{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.op("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.op("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (Expr, error) {
+	if _, ok := p.op("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errors.New(T("expr.invalid_number_at", t.text, t.pos))
+		}
+		return &literalExpr{Value{Kind: KindNumber, Num: n}}, nil
+	case tokDate:
+		if _, err := time.Parse(dateLayout, t.text); err != nil {
+			return nil, errors.New(T("expr.invalid_date_at", t.text, t.pos))
+		}
+		return &literalExpr{Value{Kind: KindDate, Str: t.text}}, nil
+	case tokString:
+		return &literalExpr{Value{Kind: KindString, Str: t.text}}, nil
+	case tokIdent:
+		switch strings.ToLower(t.text) {
+		case "true", "false":
+			return &literalExpr{Value{Kind: KindBool, Bool: strings.EqualFold(t.text, "true")}}, nil
+		case "null":
+			return &literalExpr{Value{}}, nil
+		}
+		if _, ok := p.op("("); ok {
+			return p.parseCall(t)
+		}
+		return &columnExpr{name: t.text}, nil
+	case tokOp:
+		if t.text == "(" {
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		}
+	case tokEOF:
+		return nil, errors.New(T("expr.unexpected_end"))
+	}
+	return nil, errors.New(T("expr.unexpected", t.text, t.pos))
+}
+
+func (p *exprParser) parseCall(name token) (Expr, error) {
+	fn, ok := exprFunctions[strings.ToLower(name.text)]
+	if !ok {
+		return nil, errors.New(T("expr.unknown_function_at", name.text, name.pos))
+	}
+	call := &callExpr{name: strings.ToLower(name.text), fn: fn}
+	if _, ok := p.op(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if _, ok := p.op(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(call.args) < fn.minArgs || len(call.args) > fn.maxArgs {
+		return nil, errors.New(T("expr.arity", call.name, fn.arity(), len(call.args)))
+	}
+	return call, nil
+}
+
+type literalExpr struct{ value Value }
+
+func (e *literalExpr) Eval(Row) (Value, error) { return e.value, nil }
+
+func (e *literalExpr) String() string {
+	if e.value.Kind == KindString {
+		return strconv.Quote(e.value.Str)
+	}
+	if e.value.Kind == KindNull {
+		return "null"
+	}
+	return e.value.String()
+}
+
+type columnExpr struct{ name string }
+
+func (e *columnExpr) Eval(row Row) (Value, error) { return row(e.name), nil }
+
+func (e *columnExpr) String() string {
+	for _, r := range e.name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return "`" + e.name + "`"
+		}
+	}
+	return e.name
+}
+
+type unaryExpr struct {
+	op      string
+	operand Expr
+}
+
+func (e *unaryExpr) Eval(row Row) (Value, error) {
+	v, err := e.operand.Eval(row)
+	if err != nil || v.Kind == KindNull {
+		return v, err
+	}
+	if e.op == "not" {
+		if v.Kind != KindBool {
+			return Value{}, errors.New(T("expr.not_bool", v.Kind))
+		}
+		return Value{Kind: KindBool, Bool: !v.Bool}, nil
+	}
+	if v.Kind != KindNumber {
+		return Value{}, errors.New(T("expr.minus_number", v.Kind))
+	}
+	return Value{Kind: KindNumber, Num: -v.Num}, nil
+}
+
+func (e *unaryExpr) String() string {
+	if e.op == "not" {
+		return "not " + e.operand.String()
+	}
+	return "-" + e.operand.String()
+}
+
+type binaryExpr struct {
+	op          string
+	left, right Expr
+}
+
+func (e *binaryExpr) String() string {
+	return "(" + e.left.String() + " " + e.op + " " + e.right.String() + ")"
+}
+
+func (e *binaryExpr) Eval(row Row) (Value, error) {
+	l, err := e.left.Eval(row)
+	if err != nil {
+		return Value{}, err
+	}
+	// and/or berhenti lebih awal seperti di Go
+	switch e.op {
+	case "and", "or":
+		if l.Kind != KindBool && l.Kind != KindNull {
+			return Value{}, errors.New(T("expr.op_bool", e.op, l.Kind))
+		}
+		if l.Kind == KindBool && l.Bool == (e.op == "or") {
+			return l, nil
+		}
+		r, err := e.right.Eval(row)
+		if err != nil {
+			return Value{}, err
+		}
+		if r.Kind != KindBool && r.Kind != KindNull {
+			return Value{}, errors.New(T("expr.op_bool", e.op, r.Kind))
+		}
+		if l.Kind == KindNull || r.Kind == KindNull {
+			return Value{}, nil
+		}
+		return r, nil
+	}
+
+	r, err := e.right.Eval(row)
+	if err != nil {
+		return Value{}, err
+	}
+	if l.Kind == KindNull || r.Kind == KindNull {
+		return Value{}, nil
+	}
+	switch e.op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		c, err := compareValues(l, r)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindBool, Bool: compareResult(e.op, c)}, nil
+	}
+
+	if e.op == "+" && l.Kind == KindString && r.Kind == KindString {
+		return Value{Kind: KindString, Str: l.Str + r.Str}, nil
+	}
+	if l.Kind != KindNumber || r.Kind != KindNumber {
+		return Value{}, errors.New(T("expr.op_numbers", e.op, l.Kind, r.Kind))
+	}
+	switch e.op {
+	case "+":
+		return numberValue(l.Num + r.Num), nil
+	case "-":
+		return numberValue(l.Num - r.Num), nil
+	case "*":
+		return numberValue(l.Num * r.Num), nil
+	case "/":
+		if r.Num == 0 {
+			return Value{}, errors.New(T("expr.division_by_zero"))
+		}
+		return numberValue(l.Num / r.Num), nil
+	case "%":
+		if r.Num == 0 {
+			return Value{}, errors.New(T("expr.division_by_zero"))
+		}
+		return numberValue(math.Mod(l.Num, r.Num)), nil
+	}
+	return Value{}, errors.New(T("expr.unknown_operator", e.op))
+}
+
+type betweenExpr struct {
+	value, low, high Expr
+}
+
+func (e *betweenExpr) String() string {
+	return "(" + e.value.String() + " between " + e.low.String() + " and " + e.high.String() + ")"
+}
+
+func (e *betweenExpr) Eval(row Row) (Value, error) {
+	values := make([]Value, 3)
+	for i, sub := range []Expr{e.value, e.low, e.high} {
+		v, err := sub.Eval(row)
+		if err != nil {
+			return Value{}, err
+		}
+		if v.Kind == KindNull {
+			return Value{}, nil
+		}
+		values[i] = v
+	}
+	low, err := compareValues(values[0], values[1])
+	if err != nil {
+		return Value{}, err
+	}
+	high, err := compareValues(values[0], values[2])
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{Kind: KindBool, Bool: low >= 0 && high <= 0}, nil
+}
+
+func numberValue(n float64) Value { return Value{Kind: KindNumber, Num: n} }
+
+// compareValues membandingkan dua nilai bertipe sama; string dibandingkan tanpa membedakan
+// huruf besar-kecil sehingga Status = 'on' cocok dengan "On". String berisi tanggal boleh
+// dibandingkan dengan tanggal, jadi Date = '2023-06-01' sama dengan Date = 2023-06-01.
+func compareValues(l, r Value) (int, error) {
+	l, r = coerceDate(l, r), coerceDate(r, l)
+	if l.Kind != r.Kind {
+		return 0, errors.New(T("expr.cannot_compare", l.Kind, r.Kind))
+	}
+	switch l.Kind {
+	case KindNumber:
+		switch {
+		case l.Num < r.Num:
+			return -1, nil
+		case l.Num > r.Num:
+			return 1, nil
+		}
+		return 0, nil
+	case KindString:
+		return strings.Compare(strings.ToLower(l.Str), strings.ToLower(r.Str)), nil
+	case KindDate:
+		return strings.Compare(l.Str, r.Str), nil
+	case KindBool:
+		if l.Bool == r.Bool {
+			return 0, nil
+		}
+		if r.Bool {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// coerceDate mengubah v menjadi tanggal jika other tanggal dan v string berformat tanggal
+func coerceDate(v, other Value) Value {
+	if v.Kind == KindString && other.Kind == KindDate {
+		if _, err := time.Parse(dateLayout, strings.TrimSpace(v.Str)); err == nil {
+			return Value{Kind: KindDate, Str: strings.TrimSpace(v.Str)}
+		}
+	}
+	return v
+}
+
+func compareResult(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+type exprFunction struct {
+	minArgs, maxArgs int
+	call             func(args []Value) (Value, error)
+}
+
+func (f exprFunction) arity() string {
+	if f.minArgs == f.maxArgs {
+		return T("expr.args", f.minArgs)
+	}
+	return T("expr.args_range", f.minArgs, f.maxArgs)
+}
+
+type callExpr struct {
+	name string
+	fn   exprFunction
+	args []Expr
+}
+
+func (e *callExpr) String() string {
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		args[i] = a.String()
+	}
+	return e.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (e *callExpr) Eval(row Row) (Value, error) {
+	args := make([]Value, len(e.args))
+	for i, a := range e.args {
+		v, err := a.Eval(row)
+		if err != nil {
+			return Value{}, err
+		}
+		args[i] = v
+	}
+	// Fungsi selain coalesce dan if mengembalikan null jika ada argumen null
+	if e.name != "coalesce" && e.name != "if" {
+		for _, a := range args {
+			if a.Kind == KindNull {
+				return Value{}, nil
+			}
+		}
+	}
+	v, err := e.fn.call(args)
+	if err != nil {
+		return Value{}, fmt.Errorf("%s: %w", e.name, err)
+	}
+	return v, nil
+}
+
+func numberArg(v Value) (float64, error) {
+	if v.Kind != KindNumber {
+		return 0, errors.New(T("expr.expects_number", v.Kind))
+	}
+	return v.Num, nil
+}
+
+func stringArg(v Value) (string, error) {
+	switch v.Kind {
+	case KindString, KindDate:
+		return v.Str, nil
+	case KindNumber, KindBool:
+		return v.String(), nil
+	}
+	return "", errors.New(T("expr.expects_string", v.Kind))
+}
+
+func dateFunction(part func(time.Time) float64) exprFunction {
+	return exprFunction{1, 1, func(args []Value) (Value, error) {
+		s, err := stringArg(args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		t, err := time.Parse(dateLayout, strings.TrimSpace(s))
+		if err != nil {
+			return Value{}, errors.New(T("expr.invalid_date", s))
+		}
+		return numberValue(part(t)), nil
+	}}
+}
+
+func clockFunction(hour bool) exprFunction {
+	return exprFunction{1, 1, func(args []Value) (Value, error) {
+		s, err := stringArg(args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			if t, err = time.Parse("15:04:05", strings.TrimSpace(s)); err != nil {
+				return Value{}, errors.New(T("expr.invalid_time", s))
+			}
+		}
+		if hour {
+			return numberValue(float64(t.Hour())), nil
+		}
+		return numberValue(float64(t.Minute())), nil
+	}}
+}
+
+func mathFunction(fn func(float64) float64) exprFunction {
+	return exprFunction{1, 1, func(args []Value) (Value, error) {
+		n, err := numberArg(args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		return numberValue(fn(n)), nil
+	}}
+}
+
+// exprFunctions fungsi bawaan yang boleh dipanggil dari ekspresi
+var exprFunctions map[string]exprFunction
+
+func init() {
+	exprFunctions = map[string]exprFunction{
+		"hour":    clockFunction(true),
+		"minute":  clockFunction(false),
+		"year":    dateFunction(func(t time.Time) float64 { return float64(t.Year()) }),
+		"month":   dateFunction(func(t time.Time) float64 { return float64(t.Month()) }),
+		"day":     dateFunction(func(t time.Time) float64 { return float64(t.Day()) }),
+		"weekday": dateFunction(func(t time.Time) float64 { return float64(t.Weekday()) }),
+		"yearmonth": {1, 1, func(args []Value) (Value, error) {
+			s, err := stringArg(args[0])
+			if err != nil {
+				return Value{}, err
+			}
+			t, err := time.Parse(dateLayout, strings.TrimSpace(s))
+			if err != nil {
+				return Value{}, errors.New(T("expr.invalid_date", s))
+			}
+			return Value{Kind: KindString, Str: t.Format("2006-01")}, nil
+		}},
+		"abs":   mathFunction(math.Abs),
+		"floor": mathFunction(math.Floor),
+		"ceil":  mathFunction(math.Ceil),
+		"round": {1, 2, func(args []Value) (Value, error) {
+			n, err := numberArg(args[0])
+			if err != nil {
+				return Value{}, err
+			}
+			digits := 0.0
+			if len(args) == 2 {
+				if digits, err = numberArg(args[1]); err != nil {
+					return Value{}, err
+				}
+			}
+			scale := math.Pow(10, digits)
+			return numberValue(math.Round(n*scale) / scale), nil
+		}},
+		"min": {2, 2, func(args []Value) (Value, error) {
+			c, err := compareValues(args[0], args[1])
+			if err != nil {
+				return Value{}, err
+			}
+			if c <= 0 {
+				return args[0], nil
+			}
+			return args[1], nil
+		}},
+		"max": {2, 2, func(args []Value) (Value, error) {
+			c, err := compareValues(args[0], args[1])
+			if err != nil {
+				return Value{}, err
+			}
+			if c >= 0 {
+				return args[0], nil
+			}
+			return args[1], nil
+		}},
+		"lower": {1, 1, func(args []Value) (Value, error) {
+			s, err := stringArg(args[0])
+			return Value{Kind: KindString, Str: strings.ToLower(s)}, err
+		}},
+		"upper": {1, 1, func(args []Value) (Value, error) {
+			s, err := stringArg(args[0])
+			return Value{Kind: KindString, Str: strings.ToUpper(s)}, err
+		}},
+		"contains": {2, 2, func(args []Value) (Value, error) {
+			s, err := stringArg(args[0])
+			if err != nil {
+				return Value{}, err
+			}
+			sub, err := stringArg(args[1])
+			return Value{Kind: KindBool, Bool: strings.Contains(strings.ToLower(s), strings.ToLower(sub))}, err
+		}},
+		"if": {3, 3, func(args []Value) (Value, error) {
+			if args[0].Kind != KindBool && args[0].Kind != KindNull {
+				return Value{}, errors.New(T("expr.condition_bool", args[0].Kind))
+			}
+			if args[0].Bool {
+				return args[1], nil
+			}
+			return args[2], nil
+		}},
+		"coalesce": {1, 8, func(args []Value) (Value, error) {
+			for _, a := range args {
+				if a.Kind != KindNull {
+					return a, nil
+				}
+			}
+			return Value{}, nil
+		}},
+	}
+}
+
+// ComputedColumn struct definisi kolom turunan seperti "Cost = Energy_Consumption * 1444.7"
+type ComputedColumn struct {
+	Name string
+	Expr Expr
+}
+
+// String menampilkan definisi kolom dalam bentuk yang bisa di-parse ulang
+func (c ComputedColumn) String() string {
+	return c.Name + " = " + c.Expr.String()
+}
+
+// ParseComputedColumn mem-parse definisi "Nama = ekspresi"
+func ParseComputedColumn(def string) (ComputedColumn, error) {
+	name, expr, ok := strings.Cut(def, "=")
+	name = strings.Trim(strings.TrimSpace(name), "`")
+	if !ok || name == "" || strings.HasPrefix(expr, "=") || strings.ContainsAny(name, "<>!") {
+		return ComputedColumn{}, errors.New(T("expr.computed_syntax"))
+	}
+	e, err := ParseExpr(expr)
+	if err != nil {
+		return ComputedColumn{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return ComputedColumn{Name: name, Expr: e}, nil
+}
+
+// applyComputedColumn mem-parse definisi lalu menambahkannya ke tabel
+func applyComputedColumn(table map[string][]string, def string) (map[string][]string, error) {
+	c, err := ParseComputedColumn(def)
+	if err != nil {
+		return nil, err
+	}
+	return AddComputedColumn(table, c)
+}
+
+// AddComputedColumn mengembalikan tabel baru dengan kolom turunan; tabel asli tidak diubah.
+// Kolom yang dibaca harus ada, tipe ekspresi harus cocok dengan tipe kolom, dan nama kolom baru
+// tidak boleh sudah dipakai.
+func AddComputedColumn(table map[string][]string, c ComputedColumn) (map[string][]string, error) {
+	if _, exists := table[c.Name]; exists {
+		return nil, errors.New(T("table.column_exists", c.Name))
+	}
+	schema := InferSchema(table)
+	if _, err := CheckExpr(c.Expr, schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Name, err)
+	}
+
+	rows := tableRows(table)
+	values := make([]string, rows)
+	for i := 0; i < rows; i++ {
+		v, err := c.Expr.Eval(schemaRow(table, schema, i))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", T("table.at_row", c.Name, i+1), err)
+		}
+		values[i] = v.String()
+	}
+
+	result := make(map[string][]string, len(table)+1)
+	for col, cells := range table {
+		result[col] = cells
+	}
+	result[c.Name] = values
+	return result, nil
+}
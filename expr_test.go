@@ -0,0 +1,59 @@
+package main_test
+
+import (
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Computed columns", func() {
+	table := map[string][]string{
+		"Date":               {"2023-06-01", "2023-06-02", "2023-06-03"},
+		"Time":               {"08:00", "18:30", "23:00"},
+		"Energy_Consumption": {"1.2", "2", ""},
+		"Status":             {"On", "Off", "On"},
+	}
+
+	compute := func(def string) []string {
+		c, err := main.ParseComputedColumn(def)
+		Expect(err).ShouldNot(HaveOccurred())
+		out, err := main.AddComputedColumn(table, c)
+		Expect(err).ShouldNot(HaveOccurred())
+		return out[c.Name]
+	}
+
+	It("evaluates arithmetic with empty cells as null", func() {
+		Expect(compute("Cost = Energy_Consumption * 1444.7")).Should(Equal([]string{"1733.64", "2889.4", ""}))
+		Expect(compute("Half = round(Energy_Consumption / 2, 1)")).Should(Equal([]string{"0.6", "1", ""}))
+		Expect(compute("Kwh = coalesce(Energy_Consumption, 0)")).Should(Equal([]string{"1.2", "2", "0"}))
+	})
+
+	It("supports time functions, between and boolean logic", func() {
+		Expect(compute("IsPeak = hour(Time) between 17 and 22")).Should(Equal([]string{"false", "true", "false"}))
+		Expect(compute("Active = Status = 'on' and not (day(Date) = 3)")).Should(Equal([]string{"true", "false", "false"}))
+		Expect(compute("Label = if(Energy_Consumption > 1.5, 'high', 'low')")).Should(Equal([]string{"low", "high", "low"})) // null dianggap false seperti CASE di SQL
+	})
+
+	It("does not modify the original table", func() {
+		compute("Cost = Energy_Consumption * 2")
+		Expect(table).ShouldNot(HaveKey("Cost"))
+	})
+
+	DescribeTable("rejects invalid definitions",
+		func(def, message string) {
+			c, err := main.ParseComputedColumn(def)
+			if err == nil {
+				_, err = main.AddComputedColumn(table, c)
+			}
+			Expect(err).Should(MatchError(ContainSubstring(message)))
+		},
+		Entry("missing name", "Energy_Consumption * 2", "Name = expression"),
+		Entry("unknown column", "X = Power * 2", `unknown column "Power"`),
+		Entry("existing column", "Status = 'x'", "already exists"),
+		Entry("unknown function", "X = system('ls')", `unknown function "system"`),
+		Entry("type error", "X = Status * 2", "expects numbers"),
+		Entry("syntax error", "X = (1 + 2", `expected ")"`),
+		Entry("bad time", "X = hour(Date)", "invalid time"),
+	)
+})
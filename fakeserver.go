@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FakeModelHandler http.Handler yang meniru endpoint table-QA Huggingface secara lokal.
+// Handler memilih maksimal tiga cell pertama dari kolom numerik pertama dan menjumlahkannya,
+// cukup untuk pengembangan dan benchmark tanpa token. Seperti endpoint aslinya, jawaban tidak
+// membawa probabilitas.
+// Payload SQA (`inputs.query` berupa daftar dengan `parameters.sequential`) dijawab per query dan
+// dikembalikan sebagai daftar seperti endpoint aslinya.
+func FakeModelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, `{"error":"invalid payload"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		var sequential sequentialRequest
+		if json.Unmarshal(data, &sequential) == nil && sequential.Parameters.Sequential {
+			if len(sequential.Inputs.Query) == 0 {
+				http.Error(w, `{"error":"query is required"}`, http.StatusBadRequest)
+				return
+			}
+			// Pertanyaan lanjutan hanya memilih dari baris jawaban sebelumnya
+			var answers []Response
+			var prevRows map[int]bool
+			for range sequential.Inputs.Query {
+				resp := fakeAnswer(sequential.Inputs.Table, prevRows)
+				answers = append(answers, resp)
+				prevRows = make(map[int]bool)
+				for _, c := range resp.Coordinates {
+					prevRows[c[0]] = true
+				}
+			}
+			json.NewEncoder(w).Encode(answers)
+			return
+		}
+
+		var payload Inputs
+		if err := json.Unmarshal(data, &payload); err != nil {
+			http.Error(w, `{"error":"invalid payload"}`, http.StatusBadRequest)
+			return
+		}
+		if payload.Query == "" {
+			http.Error(w, `{"error":"query is required"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(fakeAnswer(payload.Table, nil))
+	})
+}
+
+// fakeAnswer jawaban FakeModelHandler; prevRows yang tidak kosong membatasi baris yang dipilih
+func fakeAnswer(table map[string][]string, prevRows map[int]bool) Response {
+	columns := sortedColumns(table)
+	resp := Response{Aggregator: "NONE"}
+	for colIndex, col := range columns {
+		values := table[col]
+		if len(values) == 0 {
+			continue
+		}
+		if _, err := strconv.ParseFloat(values[0], 64); err != nil {
+			continue
+		}
+		for row := 0; row < len(values) && len(resp.Cells) < 3; row++ {
+			if len(prevRows) > 0 && !prevRows[row] {
+				continue
+			}
+			resp.Coordinates = append(resp.Coordinates, []int{row, colIndex})
+			resp.Cells = append(resp.Cells, values[row])
+		}
+		resp.Aggregator = "SUM"
+		break
+	}
+	if resp.Aggregator == "SUM" {
+		resp.Answer = fmt.Sprintf("SUM > %s", strings.Join(resp.Cells, ", "))
+	}
+	return resp
+}
+
+// StartFakeModel menjalankan FakeModelHandler di port acak localhost dan mengembalikan URL-nya.
+// Tidak memakai httptest agar package testing tidak ikut ke binary.
+func StartFakeModel() (url string, closeFn func(), err error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	server := &http.Server{Handler: FakeModelHandler()}
+	go server.Serve(listener)
+	return "http://" + listener.Addr().String(), func() { server.Close() }, nil
+}
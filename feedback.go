@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoHistory dikembalikan jika feedback diberikan tetapi history tidak aktif
+var ErrNoHistory = messageError("error.history_disabled")
+
+// ErrFeedbackOnError dikembalikan jika feedback diberikan untuk pertanyaan yang gagal dijawab
+var ErrFeedbackOnError = messageError("error.feedback_on_error")
+
+// LabeledExample struct satu pertanyaan dengan jawaban yang benar, dipakai untuk evaluasi
+type LabeledExample struct {
+	Question  string `json:"question"`
+	Expected  string `json:"expected"`
+	Intent    string `json:"intent"`
+	DatasetID string `json:"dataset_id,omitempty"`
+	// Filter filter deklaratif yang dipakai saat pertanyaan asli dijawab, diterapkan lagi oleh eval
+	Filter    string    `json:"filter,omitempty"`
+	HistoryID string    `json:"history_id,omitempty"`
+	Backend   string    `json:"backend,omitempty"`
+	Answer    string    `json:"answer,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// LabelStore struct penyimpanan contoh berlabel dalam file JSONL
+type LabelStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Add menambahkan satu contoh berlabel ke file
+func (s *LabelStore) Add(ex LabeledExample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(ex)
+	if err == nil {
+		data, err = sealLine(data)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadLabels membaca semua contoh berlabel dari file JSONL
+func LoadLabels(path string) ([]LabeledExample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var examples []LabeledExample
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		data, err := openLine(scanner.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		var ex LabeledExample
+		if err := json.Unmarshal(data, &ex); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if ex.Intent == "" {
+			ex.Intent = ClassifyIntent(ex.Question)
+		}
+		examples = append(examples, ex)
+	}
+	return examples, scanner.Err()
+}
+
+// RecordFeedback menyimpan penilaian user untuk entry history dan menjadikannya contoh berlabel.
+// Jika jawaban benar dan expected kosong, jawaban model dipakai sebagai label.
+func (p *Pipeline) RecordFeedback(tenant, id string, correct bool, expected string) (LabeledExample, error) {
+	if p.History == nil {
+		return LabeledExample{}, ErrNoHistory
+	}
+	entry, err := p.History.Get(id)
+	if err != nil {
+		return LabeledExample{}, err
+	}
+	if tenant != "" && entry.Tenant != tenant {
+		return LabeledExample{}, ErrEntryNotFound
+	}
+	// Pertanyaan yang gagal tidak punya jawaban model, jadi tidak boleh menjadi label "benar"
+	if entry.Error != "" {
+		return LabeledExample{}, fmt.Errorf("%w: %s", ErrFeedbackOnError, entry.Error)
+	}
+
+	answer, ok := ComputeAnswer(entry.Response)
+	if !ok {
+		answer = entry.Response.Answer
+	}
+	if correct && expected == "" {
+		expected = answer
+	}
+	if !correct && expected == "" {
+		return LabeledExample{}, errors.New(T("error.expected_required"))
+	}
+
+	if err := p.History.SetFeedback(id, Feedback{Correct: correct, Expected: expected}); err != nil {
+		return LabeledExample{}, err
+	}
+
+	ex := LabeledExample{
+		Question:  entry.Question,
+		Expected:  expected,
+		Intent:    ClassifyIntent(entry.Question),
+		DatasetID: entry.DatasetID,
+		Filter:    entry.Filter,
+		HistoryID: id,
+		Backend:   entry.Backend,
+		Answer:    answer,
+		Time:      time.Now(),
+	}
+	if p.Labels != nil {
+		if err := p.Labels.Add(ex); err != nil {
+			return LabeledExample{}, err
+		}
+	}
+	return ex, nil
+}
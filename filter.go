@@ -0,0 +1,313 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Filter deklaratif untuk power user, ditulis dengan bahasa ekspresi yang sama dengan kolom turunan:
+//
+//	Room = "Kitchen" and Date >= 2022-01-01 and Energy_Consumption > 1
+//
+// Filter diperiksa tipenya terhadap tipe kolom hasil InferSchema sebelum dijalankan, jadi
+// kesalahan seperti Room > 1 ditolak tanpa harus mengevaluasi setiap baris.
+
+var (
+	// ErrInvalidFilter dikembalikan jika filter tidak bisa di-parse atau tipenya salah
+	ErrInvalidFilter = messageError("error.invalid_filter")
+	// ErrFilterNoRows dikembalikan jika filter tidak menyisakan satu baris pun
+	ErrFilterNoRows = messageError("error.filter_no_rows")
+)
+
+// Schema tipe setiap kolom tabel
+type Schema map[string]Kind
+
+// InferSchema menebak tipe kolom dari isinya: number atau date jika semua cell yang terisi
+// bertipe itu, selain itu string. Kolom yang seluruhnya kosong bertipe null (cocok dengan apa saja).
+func InferSchema(table map[string][]string) Schema {
+	schema := make(Schema, len(table))
+	for col, cells := range table {
+		kind := KindNull
+		for _, cell := range cells {
+			var done bool
+			if kind, done = mergeKind(kind, cellValue(cell).Kind); done {
+				break
+			}
+		}
+		schema[col] = kind
+	}
+	return schema
+}
+
+// mergeKind menggabungkan tipe kolom sejauh ini dengan tipe satu cell; done true jika kolom sudah
+// pasti string sehingga cell berikutnya tidak perlu dibaca
+func mergeKind(kind, cell Kind) (Kind, bool) {
+	if cell == KindNull || cell == kind {
+		return kind, false
+	}
+	if kind != KindNull {
+		return KindString, true
+	}
+	return cell, false
+}
+
+// schemaRow membaca baris i dengan tipe dari schema, sehingga angka di kolom string tetap string
+func schemaRow(table map[string][]string, schema Schema, i int) Row {
+	return func(col string) Value {
+		cells := table[col]
+		if i >= len(cells) {
+			return Value{}
+		}
+		return schemaCell(cells[i], schema[col])
+	}
+}
+
+// schemaCell mengubah satu cell menjadi Value dengan tipe kolom kind
+func schemaCell(cell string, kind Kind) Value {
+	v := cellValue(cell)
+	if kind == KindString && v.Kind != KindNull {
+		return Value{Kind: KindString, Str: strings.TrimSpace(cell)}
+	}
+	return v
+}
+
+// readTable menyiapkan schema dan pembaca baris untuk operasi tabel. Tabel yang kolomnya sama panjang
+// dibaca lewat ColumnarTable sehingga angka dan tanggal di-parse sekali dan teks sekali per nilai unik;
+// tabel yang tidak rata dibaca cell per cell dengan schemaRow.
+func readTable(table map[string][]string) (Schema, func(i int) Row) {
+	if columnar, err := NewColumnarTable(table); err == nil {
+		schema := columnar.Schema()
+		return schema, columnar.rowReader(schema)
+	}
+	schema := InferSchema(table)
+	return schema, func(i int) Row { return schemaRow(table, schema, i) }
+}
+
+// CheckExpr memeriksa tipe ekspresi terhadap schema dan mengembalikan tipe hasilnya.
+// KindNull berarti tipenya belum diketahui (literal null atau kolom kosong) dan cocok dengan apa saja.
+func CheckExpr(e Expr, schema Schema) (Kind, error) {
+	switch n := e.(type) {
+	case *literalExpr:
+		return n.value.Kind, nil
+	case *columnExpr:
+		kind, ok := schema[n.name]
+		if !ok {
+			return KindNull, errors.New(T("table.unknown_column", n.name))
+		}
+		return kind, nil
+	case *unaryExpr:
+		kind, err := CheckExpr(n.operand, schema)
+		if err != nil {
+			return KindNull, err
+		}
+		if n.op == "not" {
+			if !kindIs(kind, KindBool) {
+				return KindNull, errors.New(T("expr.not_bool", kind))
+			}
+			return KindBool, nil
+		}
+		if !kindIs(kind, KindNumber) {
+			return KindNull, errors.New(T("expr.minus_number", kind))
+		}
+		return KindNumber, nil
+	case *binaryExpr:
+		return checkBinary(n, schema)
+	case *betweenExpr:
+		kinds, err := checkArgs([]Expr{n.value, n.low, n.high}, schema)
+		if err != nil {
+			return KindNull, err
+		}
+		for _, bound := range kinds[1:] {
+			if !comparableKinds(kinds[0], bound) {
+				return KindNull, errors.New(T("expr.cannot_compare", kinds[0], bound))
+			}
+		}
+		return KindBool, nil
+	case *callExpr:
+		kinds, err := checkArgs(n.args, schema)
+		if err != nil {
+			return KindNull, err
+		}
+		kind, err := checkCall(n.name, kinds)
+		if err != nil {
+			return KindNull, fmt.Errorf("%s: %w", n.name, err)
+		}
+		return kind, nil
+	}
+	return KindNull, errors.New(T("expr.unknown_expression", e))
+}
+
+func checkArgs(args []Expr, schema Schema) ([]Kind, error) {
+	kinds := make([]Kind, len(args))
+	for i, a := range args {
+		kind, err := CheckExpr(a, schema)
+		if err != nil {
+			return nil, err
+		}
+		kinds[i] = kind
+	}
+	return kinds, nil
+}
+
+func checkBinary(e *binaryExpr, schema Schema) (Kind, error) {
+	kinds, err := checkArgs([]Expr{e.left, e.right}, schema)
+	if err != nil {
+		return KindNull, err
+	}
+	l, r := kinds[0], kinds[1]
+	switch e.op {
+	case "and", "or":
+		for _, k := range kinds {
+			if !kindIs(k, KindBool) {
+				return KindNull, errors.New(T("expr.op_bool", e.op, k))
+			}
+		}
+		return KindBool, nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		if !comparableKinds(l, r) {
+			return KindNull, errors.New(T("expr.cannot_compare", l, r))
+		}
+		return KindBool, nil
+	}
+	if e.op == "+" && (l == KindString || r == KindString) && kindIs(l, KindString) && kindIs(r, KindString) {
+		return KindString, nil
+	}
+	if !kindIs(l, KindNumber) || !kindIs(r, KindNumber) {
+		return KindNull, errors.New(T("expr.op_numbers", e.op, l, r))
+	}
+	return KindNumber, nil
+}
+
+// checkCall tipe hasil fungsi bawaan berdasarkan tipe argumennya
+func checkCall(name string, args []Kind) (Kind, error) {
+	switch name {
+	case "hour", "minute", "year", "month", "day", "weekday":
+		if args[0] == KindBool {
+			return KindNull, errors.New(T("expr.expects_string_or_date", args[0]))
+		}
+		return KindNumber, nil
+	case "yearmonth":
+		if args[0] == KindBool {
+			return KindNull, errors.New(T("expr.expects_string_or_date", args[0]))
+		}
+		return KindString, nil
+	case "abs", "floor", "ceil", "round":
+		for _, k := range args {
+			if !kindIs(k, KindNumber) {
+				return KindNull, errors.New(T("expr.expects_number", k))
+			}
+		}
+		return KindNumber, nil
+	case "min", "max":
+		if !comparableKinds(args[0], args[1]) {
+			return KindNull, errors.New(T("expr.cannot_compare", args[0], args[1]))
+		}
+		return firstKnown(args), nil
+	case "lower", "upper":
+		return KindString, nil
+	case "contains":
+		return KindBool, nil
+	case "if":
+		if !kindIs(args[0], KindBool) {
+			return KindNull, errors.New(T("expr.condition_bool", args[0]))
+		}
+		return firstKnown(args[1:]), nil
+	case "coalesce":
+		return firstKnown(args), nil
+	}
+	return KindNull, errors.New(T("expr.unknown_function"))
+}
+
+// kindIs true jika k sama dengan want atau belum diketahui
+func kindIs(k, want Kind) bool { return k == want || k == KindNull }
+
+func comparableKinds(a, b Kind) bool {
+	if a == b || a == KindNull || b == KindNull {
+		return true
+	}
+	// String bisa berisi tanggal, dicek saat evaluasi oleh compareValues
+	return a == KindDate && b == KindString || a == KindString && b == KindDate
+}
+
+func firstKnown(kinds []Kind) Kind {
+	for _, k := range kinds {
+		if k != KindNull {
+			return k
+		}
+	}
+	return KindNull
+}
+
+// RowFilter struct filter deklaratif yang sudah di-parse dan diperiksa tipenya
+type RowFilter struct {
+	Source string
+	Expr   Expr
+}
+
+// String menampilkan filter seperti yang ditulis user
+func (f *RowFilter) String() string { return f.Source }
+
+// ParseFilter mem-parse filter dan memastikan hasilnya kondisi (bool) terhadap schema tabel
+func ParseFilter(src string, schema Schema) (*RowFilter, error) {
+	src = strings.TrimSpace(src)
+	e, err := ParseExpr(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	kind, err := CheckExpr(e, schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	if kind != KindBool {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, T("filter.not_condition", kind))
+	}
+	return &RowFilter{Source: src, Expr: e}, nil
+}
+
+// Rows mengembalikan indeks baris yang memenuhi filter; baris yang hasilnya null tidak ikut
+func (f *RowFilter) Rows(table map[string][]string) ([]int, error) {
+	_, row := readTable(table)
+	return f.rows(table, row)
+}
+
+func (f *RowFilter) rows(table map[string][]string, row func(i int) Row) ([]int, error) {
+	var rows []int
+	for i := 0; i < tableRows(table); i++ {
+		v, err := f.Expr.Eval(row(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, T("table.row", i+1), err)
+		}
+		if v.Kind == KindBool && v.Bool {
+			rows = append(rows, i)
+		}
+	}
+	return rows, nil
+}
+
+// ApplyFilter mem-parse src lalu mengembalikan tabel berisi baris yang cocok beserta indeks aslinya
+func ApplyFilter(table map[string][]string, src string) (map[string][]string, []int, error) {
+	schema, row := readTable(table)
+	f, err := ParseFilter(src, schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := f.rows(table, row)
+	if err != nil {
+		return nil, nil, err
+	}
+	return selectRows(table, rows), rows, nil
+}
+
+// composeRows menerjemahkan indeks baris hasil filter kedua ke indeks di tabel asli;
+// rows nil berarti tabel sebelumnya belum difilter
+func composeRows(rows, keep []int) []int {
+	if rows == nil {
+		return keep
+	}
+	composed := make([]int, len(keep))
+	for i, k := range keep {
+		composed[i] = rows[k]
+	}
+	return composed
+}
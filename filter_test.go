@@ -0,0 +1,105 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Declarative filters", func() {
+	table := map[string][]string{
+		"Date":               {"2021-12-31", "2022-01-05", "2022-06-01", "2022-06-02", "2023-06-01"},
+		"Energy_Consumption": {"3.1", "0.4", "1.6", "2.2", ""},
+		"Room":               {"Kitchen", "Kitchen", "Kitchen", "Bedroom", "Kitchen"},
+		"Unit":               {"101", "101", "A", "A", "101"},
+	}
+
+	It("infers column types from the data", func() {
+		Expect(main.InferSchema(table)).Should(Equal(main.Schema{
+			"Date":               main.KindDate,
+			"Energy_Consumption": main.KindNumber,
+			"Room":               main.KindString,
+			"Unit":               main.KindString,
+		}))
+	})
+
+	It("selects rows matching dates, strings and numbers", func() {
+		filtered, rows, err := main.ApplyFilter(table, `Room = "Kitchen" and Date >= 2022-01-01 and Energy_Consumption > 1`)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).Should(Equal([]int{2}))
+		Expect(filtered["Date"]).Should(Equal([]string{"2022-06-01"}))
+
+		_, rows, err = main.ApplyFilter(table, `Date between '2022-01-01' and 2022-12-31 or Unit = '101'`)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).Should(Equal([]int{0, 1, 2, 3, 4}))
+	})
+
+	DescribeTable("rejects filters that do not type-check",
+		func(src, message string) {
+			_, err := main.ParseFilter(src, main.InferSchema(table))
+			Expect(err).Should(MatchError(main.ErrInvalidFilter))
+			Expect(err).Should(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown column", "Power > 1", `unknown column "Power"`),
+		Entry("string compared to number", "Room > 1", "cannot compare string with number"),
+		Entry("number compared to date", "Energy_Consumption < 2022-01-01", "cannot compare number with date"),
+		Entry("not a condition", "Energy_Consumption * 2", "must be a condition"),
+		Entry("arithmetic on strings", "Room * 2 > 1", "expects numbers"),
+		Entry("syntax error", "Room = ", "unexpected end"),
+	)
+
+	It("applies the filter before the date filter and keeps source rows for provenance", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "1.6", Coordinates: [][]int{{0, 1}}, Cells: []string{"1.6"}, Aggregator: "NONE"}}
+		pipeline := &main.Pipeline{Backend: backend}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How much energy was used in June 2022?", Filter: `Room = "Kitchen"`})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[0].Table["Room"]).Should(Equal([]string{"Kitchen"}))
+		Expect(result.Filters[0]).Should(Equal(`Room = "Kitchen"`))
+		Expect(result.Filters).Should(HaveLen(2))
+		Expect(result.Explanation.Cells[0].SourceRow).Should(Equal(2))
+
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How much energy?", Filter: `Room = "Garage"`})
+		Expect(err).Should(MatchError(main.ErrFilterNoRows))
+		Expect(backend.asked).Should(HaveLen(1))
+	})
+
+	It("rejects invalid filters over the HTTP API", func() {
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(main.LocalPrincipal, main.DefaultDatasetID, "home.csv", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		api := httptest.NewServer(main.NewAPIServer(main.NewTableQAServer(&main.Pipeline{Backend: &fakeBackend{}}, registry)).Handler())
+		defer api.Close()
+
+		body, _ := json.Marshal(main.AskRequest{Query: "How much energy?", Filter: "Room > 1"})
+		resp, err := http.Post(api.URL+"/v1/ask", "application/json", bytes.NewReader(body))
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+	})
+
+	It("answers a batch of questions with the same filter", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "2.2", Coordinates: [][]int{{0, 1}}, Cells: []string{"2.2"}, Aggregator: "NONE"}}
+		var out bytes.Buffer
+		questions := "# kamar tidur\nHow much energy was used?\n\nWhich room used the most energy?\n"
+		err := main.RunBatch(context.Background(), &main.Pipeline{Backend: backend}, table, `Room = "Bedroom"`, 1, strings.NewReader(questions), &out)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).Should(HaveLen(2))
+		var first main.BatchResult
+		Expect(json.Unmarshal([]byte(lines[0]), &first)).Should(Succeed())
+		Expect(first.Answer).Should(Equal("2.2"))
+		Expect(first.RowsSent).Should(Equal(1))
+
+		err = main.RunBatch(context.Background(), &main.Pipeline{Backend: backend}, table, "Room >", 1, strings.NewReader(questions), &out)
+		Expect(err).Should(MatchError(main.ErrInvalidFilter))
+	})
+})
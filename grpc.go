@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"a21hc3NpZ25tZW50/tableqapb"
+)
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=a21hc3NpZ25tZW50 --go-grpc_out=. --go-grpc_opt=module=a21hc3NpZ25tZW50 tableqa/v1/tableqa.proto
+
+// DefaultDatasetID id dataset yang dimuat dari file CSV saat server start
+const DefaultDatasetID = "default"
+
+// AskRequest struct request untuk Ask dan StreamAsk
+type AskRequest struct {
+	DatasetID string `json:"dataset_id"`
+	Query     string `json:"query"`
+	// PrevQueries pertanyaan sebelumnya dalam percakapan (field query dari response), hanya dipakai
+	// server mode SQA
+	PrevQueries []string `json:"prev_queries,omitempty"`
+	// PrevCoordinates cell jawaban sebelumnya [baris asli, kolom], hanya dipakai TAPAS lokal mode SQA
+	PrevCoordinates [][]int `json:"prev_coordinates,omitempty"`
+	// Filter deklaratif yang diterapkan ke tabel sebelum pertanyaan dikirim
+	Filter string `json:"filter,omitempty"`
+}
+
+// AskResponse struct response untuk Ask
+type AskResponse struct {
+	ID string `json:"id,omitempty"`
+	// Answer jawaban dalam bahasa penanya, Query pertanyaan yang dikirim ke model
+	Answer    string   `json:"answer"`
+	Unit      string   `json:"unit,omitempty"`
+	Language  string   `json:"language"`
+	Query     string   `json:"query"`
+	Response  Response `json:"response"`
+	Filters   []string `json:"filters,omitempty"`
+	Backend   string   `json:"backend"`
+	LatencyMs int64    `json:"latency_ms"`
+	// Explanation jejak asal jawaban: baris yang dikirim, filter, agregator, cell, dan hitung ulang lokal
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+// AskEvent struct event yang dikirim oleh StreamAsk
+type AskEvent struct {
+	Stage       string       `json:"stage"`
+	Attempt     int          `json:"attempt,omitempty"`
+	WaitSeconds float64      `json:"wait_seconds,omitempty"`
+	Answer      *AskResponse `json:"answer,omitempty"`
+}
+
+// UploadDatasetRequest struct request untuk UploadDataset
+type UploadDatasetRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	CSV  string `json:"csv"`
+}
+
+// DatasetInfo struct ringkasan sebuah dataset
+type DatasetInfo struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int      `json:"rows"`
+}
+
+// ListDatasetsRequest struct request untuk ListDatasets
+type ListDatasetsRequest struct{}
+
+// ListDatasetsResponse struct response untuk ListDatasets
+type ListDatasetsResponse struct {
+	Datasets []DatasetInfo `json:"datasets"`
+}
+
+// ShareDatasetRequest struct request untuk ShareDataset
+type ShareDatasetRequest struct {
+	DatasetID string `json:"dataset_id"`
+	UserID    string `json:"user_id"`
+	// Tenant tenant user penerima, kosong berarti tenant dataset
+	Tenant string `json:"tenant,omitempty"`
+	Role   Role   `json:"role"`
+}
+
+// ShareDatasetResponse struct response kosong untuk ShareDataset
+type ShareDatasetResponse struct{}
+
+// TableQAService interface untuk service tableqa.v1.TableQA dengan struct domain. Format wire gRPC
+// adalah message protobuf di proto/tableqa/v1/tableqa.proto; struct di file ini juga menjadi body
+// JSON HTTP API.
+type TableQAService interface {
+	Ask(ctx context.Context, req *AskRequest) (*AskResponse, error)
+	StreamAsk(ctx context.Context, req *AskRequest, send func(*AskEvent) error) error
+	UploadDataset(ctx context.Context, req *UploadDatasetRequest) (*DatasetInfo, error)
+	ListDatasets(ctx context.Context, req *ListDatasetsRequest) (*ListDatasetsResponse, error)
+	ShareDataset(ctx context.Context, req *ShareDatasetRequest) (*ShareDatasetResponse, error)
+}
+
+// TableQAServer struct implementasi TableQAService di atas Pipeline dan DatasetRegistry
+type TableQAServer struct {
+	Pipeline *Pipeline
+	Registry *DatasetRegistry
+	// Auth nil berarti semua request dianggap LocalPrincipal
+	Auth Authenticator
+}
+
+// NewTableQAServer membuat server di atas registry dataset
+func NewTableQAServer(pipeline *Pipeline, registry *DatasetRegistry) *TableQAServer {
+	return &TableQAServer{Pipeline: pipeline, Registry: registry}
+}
+
+func (s *TableQAServer) question(ctx context.Context, req *AskRequest) (Question, error) {
+	id := req.DatasetID
+	if id == "" {
+		id = DefaultDatasetID
+	}
+	table, err := s.Registry.Table(principalOrLocal(ctx), id)
+	if err != nil {
+		return Question{}, grpcStatusFromError(err)
+	}
+	return Question{DatasetID: id, Table: table, Query: req.Query, PrevQueries: req.PrevQueries, PrevCoordinates: req.PrevCoordinates, Filter: req.Filter}, nil
+}
+
+func principalOrLocal(ctx context.Context) Principal {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p
+	}
+	return LocalPrincipal
+}
+
+func askResponse(result Result) *AskResponse {
+	return &AskResponse{
+		ID:          result.ID,
+		Answer:      result.Answer,
+		Unit:        result.Unit,
+		Language:    result.Language,
+		Query:       result.Query,
+		Response:    result.Response,
+		Filters:     result.Filters,
+		Backend:     result.Backend,
+		LatencyMs:   result.Latency.Milliseconds(),
+		Explanation: &result.Explanation,
+	}
+}
+
+// Ask menjawab satu pertanyaan terhadap dataset yang diminta
+func (s *TableQAServer) Ask(ctx context.Context, req *AskRequest) (*AskResponse, error) {
+	q, err := s.question(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.Pipeline.Ask(ctx, q)
+	if err != nil {
+		return nil, grpcStatusFromError(err)
+	}
+	return askResponse(result), nil
+}
+
+// StreamAsk menjawab pertanyaan sambil mengirim event "loading" selama model dimuat
+func (s *TableQAServer) StreamAsk(ctx context.Context, req *AskRequest, send func(*AskEvent) error) error {
+	q, err := s.question(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	ctx = WithProgress(ctx, func(attempt int, wait time.Duration) {
+		// Error kirim diabaikan, pembatalan stream akan terlihat dari ctx
+		_ = send(&AskEvent{Stage: "loading", Attempt: attempt, WaitSeconds: wait.Seconds()})
+	})
+	result, err := s.Pipeline.Ask(ctx, q)
+	if err != nil {
+		return grpcStatusFromError(err)
+	}
+	return send(&AskEvent{Stage: "answer", Answer: askResponse(result)})
+}
+
+// UploadDataset mem-parsing CSV dan menyimpannya sebagai dataset baru
+func (s *TableQAServer) UploadDataset(ctx context.Context, req *UploadDatasetRequest) (*DatasetInfo, error) {
+	if req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, T("error.dataset_id"))
+	}
+	table, err := CsvToSlice(req.CSV)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, T("error.invalid_csv", err))
+	}
+	name := req.Name
+	if name == "" {
+		name = req.ID
+	}
+	info, err := s.Registry.Put(principalOrLocal(ctx), req.ID, name, table)
+	if err != nil {
+		return nil, grpcStatusFromError(err)
+	}
+	return &info, nil
+}
+
+// ListDatasets mengembalikan dataset yang bisa diakses pemanggil
+func (s *TableQAServer) ListDatasets(ctx context.Context, req *ListDatasetsRequest) (*ListDatasetsResponse, error) {
+	return &ListDatasetsResponse{Datasets: s.Registry.List(principalOrLocal(ctx))}, nil
+}
+
+// ShareDataset memberi role ke user lain, hanya untuk owner dataset
+func (s *TableQAServer) ShareDataset(ctx context.Context, req *ShareDatasetRequest) (*ShareDatasetResponse, error) {
+	if err := s.Registry.Grant(principalOrLocal(ctx), req.DatasetID, Principal{UserID: req.UserID, Tenant: req.Tenant}, req.Role); err != nil {
+		return nil, grpcStatusFromError(err)
+	}
+	return &ShareDatasetResponse{}, nil
+}
+
+// authenticate membaca kredensial dari metadata "authorization: Bearer ..." atau "x-api-key"
+func (s *TableQAServer) authenticate(ctx context.Context) (context.Context, error) {
+	if s.Auth == nil {
+		return WithPrincipal(ctx, LocalPrincipal), nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	credential := ""
+	if values := md.Get("authorization"); len(values) > 0 {
+		credential = strings.TrimPrefix(values[0], "Bearer ")
+	} else if values := md.Get("x-api-key"); len(values) > 0 {
+		credential = values[0]
+	}
+	p, err := s.Auth.Authenticate(credential)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+// UnaryInterceptor interceptor gRPC untuk autentikasi RPC unary
+func (s *TableQAServer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor interceptor gRPC untuk autentikasi RPC streaming
+func (s *TableQAServer) StreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcStatusFromError memetakan error dari connector ke status code gRPC
+func grpcStatusFromError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var modelErr *ModelError
+	var urlErr *url.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, ErrEmptyQuery), errors.Is(err, ErrInvalidRole), errors.Is(err, ErrInvalidFilter), errors.Is(err, ErrFilterNoRows),
+		errors.Is(err, ErrTableTooLarge), errors.Is(err, ErrFeedbackOnError):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, ErrDatasetNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrQuotaExceeded), errors.Is(err, ErrOverloaded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrMaxRetries):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.As(err, &modelErr):
+		return status.Error(codeFromHTTPStatus(modelErr.StatusCode), err.Error())
+	case errors.As(err, &urlErr):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func codeFromHTTPStatus(code int) codes.Code {
+	switch {
+	case code == 400 || code == 422:
+		return codes.InvalidArgument
+	case code == 401:
+		return codes.Unauthenticated
+	case code == 403:
+		return codes.PermissionDenied
+	case code == 404:
+		return codes.NotFound
+	case code == 429:
+		return codes.ResourceExhausted
+	case code == 503 || code == 502 || code == 504:
+		return codes.Unavailable
+	case code >= 500:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// RegisterTableQAServer mendaftarkan service ke grpc.Server dengan stub hasil generate di tableqapb
+func RegisterTableQAServer(s *grpc.Server, srv TableQAService) {
+	tableqapb.RegisterTableQAServer(s, &pbServer{srv: srv})
+}
+
+// TableQAClient struct client Go untuk service TableQA dengan struct domain di atas stub tableqapb
+type TableQAClient struct {
+	pb tableqapb.TableQAClient
+}
+
+// NewTableQAClient membuat client dari koneksi gRPC
+func NewTableQAClient(cc grpc.ClientConnInterface) *TableQAClient {
+	return &TableQAClient{pb: tableqapb.NewTableQAClient(cc)}
+}
+
+// Ask memanggil RPC Ask
+func (c *TableQAClient) Ask(ctx context.Context, in *AskRequest, opts ...grpc.CallOption) (*AskResponse, error) {
+	out, err := c.pb.Ask(ctx, askRequestToPB(in), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return askResponseFromPB(out), nil
+}
+
+// UploadDataset memanggil RPC UploadDataset
+func (c *TableQAClient) UploadDataset(ctx context.Context, in *UploadDatasetRequest, opts ...grpc.CallOption) (*DatasetInfo, error) {
+	out, err := c.pb.UploadDataset(ctx, &tableqapb.UploadDatasetRequest{Id: in.ID, Name: in.Name, Csv: in.CSV}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	info := datasetInfoFromPB(out)
+	return &info, nil
+}
+
+// ListDatasets memanggil RPC ListDatasets
+func (c *TableQAClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
+	out, err := c.pb.ListDatasets(ctx, &tableqapb.ListDatasetsRequest{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListDatasetsResponse{}
+	for _, d := range out.Datasets {
+		resp.Datasets = append(resp.Datasets, datasetInfoFromPB(d))
+	}
+	return resp, nil
+}
+
+// ShareDataset memanggil RPC ShareDataset
+func (c *TableQAClient) ShareDataset(ctx context.Context, in *ShareDatasetRequest, opts ...grpc.CallOption) (*ShareDatasetResponse, error) {
+	_, err := c.pb.ShareDataset(ctx, &tableqapb.ShareDatasetRequest{DatasetId: in.DatasetID, UserId: in.UserID, Role: string(in.Role), Tenant: in.Tenant}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ShareDatasetResponse{}, nil
+}
+
+// StreamAsk memanggil RPC StreamAsk dan meneruskan setiap event ke fn
+func (c *TableQAClient) StreamAsk(ctx context.Context, in *AskRequest, fn func(*AskEvent) error, opts ...grpc.CallOption) error {
+	stream, err := c.pb.StreamAsk(ctx, askRequestToPB(in), opts...)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := fn(askEventFromPB(event)); err != nil {
+			return err
+		}
+	}
+}
+
+// ServeGRPC menjalankan server gRPC pada listener sampai server dihentikan
+func ServeGRPC(lis net.Listener, srv *TableQAServer, opts ...grpc.ServerOption) (*grpc.Server, <-chan error) {
+	opts = append(opts, grpc.UnaryInterceptor(srv.UnaryInterceptor), grpc.StreamInterceptor(srv.StreamInterceptor))
+	s := grpc.NewServer(opts...)
+	RegisterTableQAServer(s, srv)
+	errc := make(chan error, 1)
+	go func() { errc <- s.Serve(lis) }()
+	return s, errc
+}
@@ -0,0 +1,133 @@
+package main_test
+
+import (
+	"context"
+	"net"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+	"a21hc3NpZ25tZW50/tableqapb"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeBackend struct {
+	name     string
+	response main.Response
+	err      error
+	loading  int
+	asked    []main.Inputs
+}
+
+func (f *fakeBackend) Name() string {
+	if f.name == "" {
+		return "fake"
+	}
+	return f.name
+}
+
+func (f *fakeBackend) Ask(ctx context.Context, payload main.Inputs) (main.Response, error) {
+	f.asked = append(f.asked, payload)
+	if progress := main.ProgressFromContext(ctx); progress != nil {
+		for i := 1; i <= f.loading; i++ {
+			progress(i, time.Second)
+		}
+	}
+	return f.response, f.err
+}
+
+var _ = Describe("gRPC TableQA", func() {
+	var (
+		backend *fakeBackend
+		client  *main.TableQAClient
+		server  *grpc.Server
+		conn    *grpc.ClientConn
+	)
+
+	BeforeEach(func() {
+		backend = &fakeBackend{response: main.Response{Answer: "1.2", Cells: []string{"1.2"}, Aggregator: "NONE"}}
+		table := map[string][]string{"Appliance": {"Fridge"}, "Energy_Consumption": {"1.2"}}
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(main.LocalPrincipal, main.DefaultDatasetID, "data-series.csv", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		srv := main.NewTableQAServer(&main.Pipeline{Backend: backend}, registry)
+
+		lis := bufconn.Listen(1 << 20)
+		server, _ = main.ServeGRPC(lis, srv)
+
+		conn, err = grpc.Dial("bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).ShouldNot(HaveOccurred())
+		client = main.NewTableQAClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	It("answers questions against the default dataset", func() {
+		resp, err := client.Ask(context.Background(), &main.AskRequest{Query: "How much does the fridge use?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Response.Answer).Should(Equal("1.2"))
+		Expect(resp.Backend).Should(Equal("fake"))
+	})
+
+	It("serves protobuf messages to clients generated from the .proto", func() {
+		backend.response = main.Response{Answer: "1.2", Coordinates: [][]int{{0, 1}}, Cells: []string{"1.2"}, Aggregator: "NONE", CellProbabilities: []float64{0.9}}
+		pb := tableqapb.NewTableQAClient(conn)
+		resp, err := pb.Ask(context.Background(), &tableqapb.AskRequest{Query: "How much does the fridge use?", PrevQueries: []string{"Which appliance is in the kitchen?"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Response.Answer).Should(Equal("1.2"))
+		Expect(resp.Response.Coordinates).Should(HaveLen(1))
+		Expect(resp.Response.Coordinates[0].Column).Should(Equal(int32(1)))
+		Expect(resp.Explanation.GetConfidence()).Should(BeNumerically("~", 0.9))
+		Expect(resp.Explanation.Cells[0].Column).Should(Equal("Energy_Consumption"))
+
+		list, err := pb.ListDatasets(context.Background(), &tableqapb.ListDatasetsRequest{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Datasets[0].Id).Should(Equal(main.DefaultDatasetID))
+	})
+
+	It("uploads and lists datasets", func() {
+		info, err := client.UploadDataset(context.Background(), &main.UploadDatasetRequest{ID: "home-2", CSV: "Room,Energy_Consumption\nKitchen,2\nGarage,3"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(info.Rows).Should(Equal(2))
+
+		list, err := client.ListDatasets(context.Background(), &main.ListDatasetsRequest{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Datasets).Should(HaveLen(2))
+		Expect(list.Datasets[1].Columns).Should(Equal([]string{"Energy_Consumption", "Room"}))
+	})
+
+	It("streams loading progress before the answer", func() {
+		backend.loading = 2
+		var stages []string
+		err := client.StreamAsk(context.Background(), &main.AskRequest{Query: "total?"}, func(e *main.AskEvent) error {
+			stages = append(stages, e.Stage)
+			return nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stages).Should(Equal([]string{"loading", "loading", "answer"}))
+	})
+
+	It("maps connector errors to status codes", func() {
+		backend.err = &main.ModelError{StatusCode: 401, Status: "401 Unauthorized"}
+		_, err := client.Ask(context.Background(), &main.AskRequest{Query: "total?"})
+		Expect(status.Code(err)).Should(Equal(codes.Unauthenticated))
+
+		backend.err = main.ErrMaxRetries
+		_, err = client.Ask(context.Background(), &main.AskRequest{Query: "total?"})
+		Expect(status.Code(err)).Should(Equal(codes.Unavailable))
+
+		_, err = client.Ask(context.Background(), &main.AskRequest{DatasetID: "missing", Query: "total?"})
+		Expect(status.Code(err)).Should(Equal(codes.NotFound))
+	})
+})
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+
+	"a21hc3NpZ25tZW50/tableqapb"
+)
+
+// pbServer adapter dari stub tableqapb ke TableQAService: setiap message protobuf diubah ke struct
+// domain sebelum diteruskan, dan hasilnya diubah kembali.
+type pbServer struct {
+	tableqapb.UnimplementedTableQAServer
+	srv TableQAService
+}
+
+func (s *pbServer) Ask(ctx context.Context, req *tableqapb.AskRequest) (*tableqapb.AskResponse, error) {
+	resp, err := s.srv.Ask(ctx, askRequestFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+	return askResponseToPB(resp), nil
+}
+
+func (s *pbServer) StreamAsk(req *tableqapb.AskRequest, stream tableqapb.TableQA_StreamAskServer) error {
+	return s.srv.StreamAsk(stream.Context(), askRequestFromPB(req), func(e *AskEvent) error {
+		return stream.Send(askEventToPB(e))
+	})
+}
+
+func (s *pbServer) UploadDataset(ctx context.Context, req *tableqapb.UploadDatasetRequest) (*tableqapb.DatasetInfo, error) {
+	info, err := s.srv.UploadDataset(ctx, &UploadDatasetRequest{ID: req.Id, Name: req.Name, CSV: req.Csv})
+	if err != nil {
+		return nil, err
+	}
+	return datasetInfoToPB(*info), nil
+}
+
+func (s *pbServer) ListDatasets(ctx context.Context, req *tableqapb.ListDatasetsRequest) (*tableqapb.ListDatasetsResponse, error) {
+	list, err := s.srv.ListDatasets(ctx, &ListDatasetsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	out := &tableqapb.ListDatasetsResponse{}
+	for _, d := range list.Datasets {
+		out.Datasets = append(out.Datasets, datasetInfoToPB(d))
+	}
+	return out, nil
+}
+
+func (s *pbServer) ShareDataset(ctx context.Context, req *tableqapb.ShareDatasetRequest) (*tableqapb.ShareDatasetResponse, error) {
+	_, err := s.srv.ShareDataset(ctx, &ShareDatasetRequest{DatasetID: req.DatasetId, UserID: req.UserId, Tenant: req.Tenant, Role: Role(req.Role)})
+	if err != nil {
+		return nil, err
+	}
+	return &tableqapb.ShareDatasetResponse{}, nil
+}
+
+func coordinatesToPB(coords [][]int) []*tableqapb.Coordinate {
+	var out []*tableqapb.Coordinate
+	for _, c := range coords {
+		if len(c) < 2 {
+			continue
+		}
+		out = append(out, &tableqapb.Coordinate{Row: int32(c[0]), Column: int32(c[1])})
+	}
+	return out
+}
+
+func coordinatesFromPB(coords []*tableqapb.Coordinate) [][]int {
+	var out [][]int
+	for _, c := range coords {
+		out = append(out, []int{int(c.Row), int(c.Column)})
+	}
+	return out
+}
+
+func askRequestToPB(req *AskRequest) *tableqapb.AskRequest {
+	return &tableqapb.AskRequest{
+		DatasetId:       req.DatasetID,
+		Query:           req.Query,
+		PrevQueries:     req.PrevQueries,
+		PrevCoordinates: coordinatesToPB(req.PrevCoordinates),
+		Filter:          req.Filter,
+	}
+}
+
+func askRequestFromPB(req *tableqapb.AskRequest) *AskRequest {
+	return &AskRequest{
+		DatasetID:       req.DatasetId,
+		Query:           req.Query,
+		PrevQueries:     req.PrevQueries,
+		PrevCoordinates: coordinatesFromPB(req.PrevCoordinates),
+		Filter:          req.Filter,
+	}
+}
+
+func responseToPB(r Response) *tableqapb.ModelResponse {
+	return &tableqapb.ModelResponse{
+		Answer:                  r.Answer,
+		Coordinates:             coordinatesToPB(r.Coordinates),
+		Cells:                   r.Cells,
+		Aggregator:              r.Aggregator,
+		CellProbabilities:       r.CellProbabilities,
+		AggregatorProbabilities: r.AggregatorProbabilities,
+	}
+}
+
+func responseFromPB(r *tableqapb.ModelResponse) Response {
+	if r == nil {
+		return Response{}
+	}
+	return Response{
+		Answer:                  r.Answer,
+		Coordinates:             coordinatesFromPB(r.Coordinates),
+		Cells:                   r.Cells,
+		Aggregator:              r.Aggregator,
+		CellProbabilities:       r.CellProbabilities,
+		AggregatorProbabilities: r.AggregatorProbabilities,
+	}
+}
+
+func explanationToPB(e *Explanation) *tableqapb.Explanation {
+	if e == nil {
+		return nil
+	}
+	out := &tableqapb.Explanation{
+		Original:       e.Original,
+		Query:          e.Query,
+		Language:       e.Language,
+		Intent:         e.Intent,
+		Filters:        e.Filters,
+		RowsTotal:      int32(e.RowsTotal),
+		RowsSent:       int32(e.RowsSent),
+		SentRows:       e.SentRows,
+		Aggregator:     e.Aggregator,
+		ModelAnswer:    e.ModelAnswer,
+		Recomputed:     e.Recomputed,
+		CellsMatch:     e.CellsMatch,
+		Confidence:     e.Confidence,
+		PreAggregation: e.PreAggregation,
+	}
+	for _, c := range e.Corrections {
+		out.Corrections = append(out.Corrections, &tableqapb.Correction{From: c.From, To: c.To})
+	}
+	for _, c := range e.Cells {
+		out.Cells = append(out.Cells, &tableqapb.CellProvenance{Row: int32(c.Row), SourceRow: int32(c.SourceRow), Column: c.Column, Value: c.Value, Reported: c.Reported})
+	}
+	for _, r := range e.Route {
+		out.Route = append(out.Route, &tableqapb.RouteAttempt{Backend: r.Backend, Confidence: r.Confidence, Scored: r.Scored, Accepted: r.Accepted, Error: r.Error})
+	}
+	if t := e.Tokens; t != nil {
+		out.Tokens = &tableqapb.TokenEstimate{
+			Query: int32(t.Query), Header: int32(t.Header), Cells: int32(t.Cells), Total: int32(t.Total),
+			Limit: int32(t.Limit), Rows: int32(t.Rows), RowsFit: int32(t.RowsFit), Truncated: t.Truncated,
+		}
+	}
+	return out
+}
+
+func explanationFromPB(e *tableqapb.Explanation) *Explanation {
+	if e == nil {
+		return nil
+	}
+	out := &Explanation{
+		Original:       e.Original,
+		Query:          e.Query,
+		Language:       e.Language,
+		Intent:         e.Intent,
+		Filters:        e.Filters,
+		RowsTotal:      int(e.RowsTotal),
+		RowsSent:       int(e.RowsSent),
+		SentRows:       e.SentRows,
+		Aggregator:     e.Aggregator,
+		ModelAnswer:    e.ModelAnswer,
+		Recomputed:     e.Recomputed,
+		CellsMatch:     e.CellsMatch,
+		Confidence:     e.Confidence,
+		PreAggregation: e.PreAggregation,
+	}
+	for _, c := range e.Corrections {
+		out.Corrections = append(out.Corrections, Correction{From: c.From, To: c.To})
+	}
+	for _, c := range e.Cells {
+		out.Cells = append(out.Cells, CellProvenance{Row: int(c.Row), SourceRow: int(c.SourceRow), Column: c.Column, Value: c.Value, Reported: c.Reported})
+	}
+	for _, r := range e.Route {
+		out.Route = append(out.Route, RouteAttempt{Backend: r.Backend, Confidence: r.Confidence, Scored: r.Scored, Accepted: r.Accepted, Error: r.Error})
+	}
+	if t := e.Tokens; t != nil {
+		out.Tokens = &TokenEstimate{
+			Query: int(t.Query), Header: int(t.Header), Cells: int(t.Cells), Total: int(t.Total),
+			Limit: int(t.Limit), Rows: int(t.Rows), RowsFit: int(t.RowsFit), Truncated: t.Truncated,
+		}
+	}
+	return out
+}
+
+func askResponseToPB(r *AskResponse) *tableqapb.AskResponse {
+	if r == nil {
+		return nil
+	}
+	return &tableqapb.AskResponse{
+		Response:    responseToPB(r.Response),
+		Backend:     r.Backend,
+		LatencyMs:   r.LatencyMs,
+		Id:          r.ID,
+		Filters:     r.Filters,
+		Answer:      r.Answer,
+		Language:    r.Language,
+		Query:       r.Query,
+		Unit:        r.Unit,
+		Explanation: explanationToPB(r.Explanation),
+	}
+}
+
+func askResponseFromPB(r *tableqapb.AskResponse) *AskResponse {
+	if r == nil {
+		return nil
+	}
+	return &AskResponse{
+		ID:          r.Id,
+		Answer:      r.Answer,
+		Unit:        r.Unit,
+		Language:    r.Language,
+		Query:       r.Query,
+		Response:    responseFromPB(r.Response),
+		Filters:     r.Filters,
+		Backend:     r.Backend,
+		LatencyMs:   r.LatencyMs,
+		Explanation: explanationFromPB(r.Explanation),
+	}
+}
+
+func askEventToPB(e *AskEvent) *tableqapb.AskEvent {
+	return &tableqapb.AskEvent{Stage: e.Stage, Attempt: int32(e.Attempt), WaitSeconds: e.WaitSeconds, Answer: askResponseToPB(e.Answer)}
+}
+
+func askEventFromPB(e *tableqapb.AskEvent) *AskEvent {
+	return &AskEvent{Stage: e.Stage, Attempt: int(e.Attempt), WaitSeconds: e.WaitSeconds, Answer: askResponseFromPB(e.Answer)}
+}
+
+func datasetInfoToPB(d DatasetInfo) *tableqapb.DatasetInfo {
+	return &tableqapb.DatasetInfo{Id: d.ID, Name: d.Name, Columns: d.Columns, Rows: int32(d.Rows)}
+}
+
+func datasetInfoFromPB(d *tableqapb.DatasetInfo) DatasetInfo {
+	return DatasetInfo{ID: d.Id, Name: d.Name, Columns: d.Columns, Rows: int(d.Rows)}
+}
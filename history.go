@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrEntryNotFound dikembalikan jika id history tidak ditemukan
+var ErrEntryNotFound = messageError("error.entry_not_found")
+
+// Feedback struct penilaian user terhadap sebuah jawaban
+type Feedback struct {
+	Correct  bool      `json:"correct"`
+	Expected string    `json:"expected,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// HistoryEntry struct satu pertanyaan yang pernah diajukan beserta hasilnya
+type HistoryEntry struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	User      string    `json:"user,omitempty"`
+	Tenant    string    `json:"tenant,omitempty"`
+	DatasetID string    `json:"dataset_id,omitempty"`
+	Question  string    `json:"question"`
+	// Translated pertanyaan versi bahasa Inggris jika user bertanya dalam bahasa lain
+	Translated string `json:"translated,omitempty"`
+	// Filter filter deklaratif dari user (Question.Filter); Filters berisi semua filter yang diterapkan
+	Filter    string    `json:"filter,omitempty"`
+	Filters   []string  `json:"filters,omitempty"`
+	Backend   string    `json:"backend"`
+	Response  Response  `json:"response"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	Feedback  *Feedback `json:"feedback,omitempty"`
+}
+
+// historyRecord satu baris JSONL; feedback ditulis sebagai record terpisah agar file tetap append-only
+type historyRecord struct {
+	Entry    *HistoryEntry `json:"entry,omitempty"`
+	Feedback *struct {
+		ID string `json:"id"`
+		Feedback
+	} `json:"feedback,omitempty"`
+}
+
+// DefaultPruneInterval jarak Prune berkala selama HistoryStore terbuka
+const DefaultPruneInterval = time.Hour
+
+// Retention struct aturan penyimpanan history, nilai 0 berarti tidak dibatasi
+type Retention struct {
+	MaxAge     time.Duration
+	MaxEntries int
+	// PruneInterval jarak Prune berkala selama store terbuka, 0 berarti DefaultPruneInterval
+	PruneInterval time.Duration
+}
+
+func (r Retention) enabled() bool {
+	return r.MaxAge > 0 || r.MaxEntries > 0
+}
+
+// HistoryQuery struct filter untuk mencari history
+type HistoryQuery struct {
+	Text   string
+	User   string
+	Tenant string
+	Since  time.Time
+	Limit  int
+}
+
+// HistoryStore struct penyimpanan history append-only dalam file JSONL
+type HistoryStore struct {
+	Path      string
+	Retention Retention
+
+	mu      sync.Mutex
+	file    *os.File
+	entries []*HistoryEntry
+	byID    map[string]*HistoryEntry
+	seq     int
+	// stop menghentikan goroutine Prune berkala, done ditutup setelah goroutine selesai
+	stop chan struct{}
+	done chan struct{}
+}
+
+// OpenHistory membuka (atau membuat) file history dan menerapkan retention. Selama store terbuka
+// retention diterapkan lagi setiap Retention.PruneInterval dan saat jumlah entry melewati MaxEntries.
+func OpenHistory(path string, retention Retention) (*HistoryStore, error) {
+	h := &HistoryStore{Path: path, Retention: retention, byID: make(map[string]*HistoryEntry)}
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+	if err := h.compact(time.Now()); err != nil {
+		return nil, err
+	}
+	if retention.enabled() {
+		interval := retention.PruneInterval
+		if interval <= 0 {
+			interval = DefaultPruneInterval
+		}
+		h.stop, h.done = make(chan struct{}), make(chan struct{})
+		go h.pruneEvery(interval, h.stop, h.done)
+	}
+	return h, nil
+}
+
+func (h *HistoryStore) pruneEvery(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := h.Prune(); err != nil {
+				log.Println(T("history.prune_error", err))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (h *HistoryStore) load() error {
+	f, err := os.Open(h.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		data, err := openLine(scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", h.Path, line, err)
+		}
+		var rec historyRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return fmt.Errorf("%s:%d: %w", h.Path, line, err)
+		}
+		h.apply(rec)
+	}
+	return scanner.Err()
+}
+
+func (h *HistoryStore) apply(rec historyRecord) {
+	switch {
+	case rec.Entry != nil:
+		h.entries = append(h.entries, rec.Entry)
+		h.byID[rec.Entry.ID] = rec.Entry
+	case rec.Feedback != nil:
+		if entry, ok := h.byID[rec.Feedback.ID]; ok {
+			fb := rec.Feedback.Feedback
+			entry.Feedback = &fb
+		}
+	}
+}
+
+// compact membuang entry yang melewati retention lalu menulis ulang file
+func (h *HistoryStore) compact(now time.Time) error {
+	keep := h.entries[:0]
+	for _, e := range h.entries {
+		if h.Retention.MaxAge > 0 && now.Sub(e.Time) > h.Retention.MaxAge {
+			delete(h.byID, e.ID)
+			continue
+		}
+		keep = append(keep, e)
+	}
+	if h.Retention.MaxEntries > 0 && len(keep) > h.Retention.MaxEntries {
+		for _, e := range keep[:len(keep)-h.Retention.MaxEntries] {
+			delete(h.byID, e.ID)
+		}
+		keep = keep[len(keep)-h.Retention.MaxEntries:]
+	}
+	h.entries = keep
+
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o700); err != nil {
+		return err
+	}
+	tmp := h.Path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, e := range h.entries {
+		if err := writeHistoryRecord(w, historyRecord{Entry: e}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, h.Path); err != nil {
+		return err
+	}
+
+	h.file, err = os.OpenFile(h.Path, os.O_APPEND|os.O_WRONLY, 0o600)
+	return err
+}
+
+func writeHistoryRecord(w interface{ Write([]byte) (int, error) }, rec historyRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if data, err = sealLine(data); err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// Record menambahkan entry baru dan mengembalikan id-nya
+func (h *HistoryStore) Record(entry HistoryEntry) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	h.seq++
+	entry.ID = fmt.Sprintf("%d-%d", entry.Time.UnixNano(), h.seq)
+	if err := writeHistoryRecord(h.file, historyRecord{Entry: &entry}); err != nil {
+		return "", err
+	}
+	h.entries = append(h.entries, &entry)
+	h.byID[entry.ID] = &entry
+	// File ditulis ulang setelah kelebihan 10% agar tidak terjadi di setiap Record
+	if max := h.Retention.MaxEntries; max > 0 && len(h.entries) > max+max/10 {
+		if err := h.compact(time.Now()); err != nil {
+			// Entry sudah tersimpan; retention dicoba lagi di Record atau Prune berikutnya
+			log.Println(T("history.prune_error", err))
+		}
+	}
+	return entry.ID, nil
+}
+
+// SetFeedback menyimpan feedback user untuk entry dengan id tertentu
+func (h *HistoryStore) SetFeedback(id string, fb Feedback) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.byID[id]
+	if !ok {
+		return ErrEntryNotFound
+	}
+	if fb.Time.IsZero() {
+		fb.Time = time.Now()
+	}
+	rec := historyRecord{Feedback: &struct {
+		ID string `json:"id"`
+		Feedback
+	}{ID: id, Feedback: fb}}
+	if err := writeHistoryRecord(h.file, rec); err != nil {
+		return err
+	}
+	entry.Feedback = &fb
+	return nil
+}
+
+// Get mengambil salinan entry berdasarkan id
+func (h *HistoryStore) Get(id string) (HistoryEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry, ok := h.byID[id]
+	if !ok {
+		return HistoryEntry{}, ErrEntryNotFound
+	}
+	return *entry, nil
+}
+
+// Search mencari entry terbaru yang cocok dengan query, urut dari yang terbaru
+func (h *HistoryStore) Search(q HistoryQuery) []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	text := strings.ToLower(q.Text)
+	results := make([]HistoryEntry, 0)
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		e := h.entries[i]
+		if q.User != "" && e.User != q.User || q.Tenant != "" && e.Tenant != q.Tenant {
+			continue
+		}
+		if !q.Since.IsZero() && e.Time.Before(q.Since) {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(e.Question+" "+e.Translated+" "+e.Response.Answer), text) {
+			continue
+		}
+		results = append(results, *e)
+		if q.Limit > 0 && len(results) == q.Limit {
+			break
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Time.After(results[j].Time) })
+	return results
+}
+
+// Prune menerapkan retention sekarang; OpenHistory juga memanggilnya secara berkala
+func (h *HistoryStore) Prune() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		// Store sudah ditutup
+		return nil
+	}
+	return h.compact(time.Now())
+}
+
+// Close menghentikan Prune berkala lalu menutup file history
+func (h *HistoryStore) Close() error {
+	h.mu.Lock()
+	stop := h.stop
+	h.stop = nil
+	h.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-h.done
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	// Sync memastikan entri terakhir sudah ada di disk sebelum proses berhenti
+	err := h.file.Sync()
+	if cerr := h.file.Close(); err == nil {
+		err = cerr
+	}
+	h.file = nil
+	return err
+}
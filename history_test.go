@@ -0,0 +1,152 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HistoryStore", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "history.jsonl")
+	})
+
+	It("persists entries and feedback across reopen", func() {
+		h, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		id, err := h.Record(main.HistoryEntry{Question: "total in kitchen?", Backend: "fake", Response: main.Response{Answer: "4.2"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(h.SetFeedback(id, main.Feedback{Correct: false, Expected: "3.6"})).Should(Succeed())
+		Expect(h.Close()).Should(Succeed())
+
+		h, err = main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer h.Close()
+		entries := h.Search(main.HistoryQuery{Text: "KITCHEN"})
+		Expect(entries).Should(HaveLen(1))
+		Expect(entries[0].Feedback.Expected).Should(Equal("3.6"))
+	})
+
+	It("applies retention when opened", func() {
+		h, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = h.Record(main.HistoryEntry{Time: time.Now().Add(-48 * time.Hour), Question: "old"})
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, q := range []string{"a", "b", "c"} {
+			_, err = h.Record(main.HistoryEntry{Question: q})
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+		Expect(h.Close()).Should(Succeed())
+
+		h, err = main.OpenHistory(path, main.Retention{MaxAge: 24 * time.Hour, MaxEntries: 2})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer h.Close()
+		entries := h.Search(main.HistoryQuery{})
+		Expect(entries).Should(HaveLen(2))
+		Expect(entries[0].Question).Should(Equal("c"))
+	})
+
+	It("applies retention while the store is open", func() {
+		h, err := main.OpenHistory(path, main.Retention{MaxAge: 200 * time.Millisecond, MaxEntries: 2, PruneInterval: 20 * time.Millisecond})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer h.Close()
+		for _, q := range []string{"a", "b", "c"} {
+			_, err = h.Record(main.HistoryEntry{Question: q})
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+		// Melewati MaxEntries langsung dipangkas, termasuk di file
+		Expect(h.Search(main.HistoryQuery{})).Should(HaveLen(2))
+		data, err := ioutil.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(strings.Count(string(data), "\n")).Should(Equal(2))
+
+		// Entry yang melewati MaxAge dibuang oleh Prune berkala tanpa Record baru
+		Eventually(func() []main.HistoryEntry { return h.Search(main.HistoryQuery{}) }).Should(BeEmpty())
+	})
+
+	It("records resolved filters from the pipeline and serves them over HTTP", func() {
+		h, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer h.Close()
+
+		backend := &fakeBackend{response: main.Response{Answer: "2.4"}}
+		pipeline := &main.Pipeline{Backend: backend, History: h}
+		table := map[string][]string{
+			"Date":               {"2023-05-31", "2023-06-01", "2023-06-02"},
+			"Energy_Consumption": {"1.0", "1.2", "1.2"},
+		}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How much power was consumed in June 2023?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.RowsSent).Should(Equal(2))
+		Expect(backend.asked[0].Table["Date"]).Should(Equal([]string{"2023-06-01", "2023-06-02"}))
+
+		registry := main.NewDatasetRegistry(main.Quota{})
+		api := httptest.NewServer(main.NewAPIServer(main.NewTableQAServer(pipeline, registry)).Handler())
+		defer api.Close()
+
+		resp, err := http.Get(api.URL + "/v1/history?q=june")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		var body struct{ Entries []main.HistoryEntry }
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).Should(Succeed())
+		Expect(body.Entries).Should(HaveLen(1))
+		Expect(body.Entries[0].Filters[0]).Should(ContainSubstring("2023-06-01..2023-06-30"))
+	})
+	It("records questions that fail before reaching the backend", func() {
+		h, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer h.Close()
+
+		backend := &fakeBackend{response: main.Response{Answer: "2.4"}}
+		pipeline := &main.Pipeline{Backend: backend, History: h}
+		table := map[string][]string{
+			"Appliance":          {"TV", "Fridge"},
+			"Energy_Consumption": {"1.0", "1.2"},
+		}
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How much energy?", Filter: "Energy_Consumption >"})
+		Expect(err).Should(HaveOccurred())
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliance?", Filter: "Energy_Consumption > 100"})
+		Expect(err).Should(MatchError(main.ErrFilterNoRows))
+		Expect(result.ID).ShouldNot(BeEmpty())
+		pipeline.MaxTokens = 1
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy?"})
+		Expect(err).Should(MatchError(main.ErrTableTooLarge))
+		// Pertanyaan kosong ditolak sebelum validasi selesai dan tidak dicatat
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: " "})
+		Expect(err).Should(MatchError(main.ErrEmptyQuery))
+
+		Expect(backend.asked).Should(BeEmpty())
+		entries := h.Search(main.HistoryQuery{})
+		Expect(entries).Should(HaveLen(3))
+		for _, e := range entries {
+			Expect(e.Error).ShouldNot(BeEmpty())
+		}
+		Expect(h.Get(result.ID)).Should(HaveField("Filter", "Energy_Consumption > 100"))
+	})
+})
+
+var _ = Describe("ResolveDateFilter", func() {
+	It("prefers the most specific date mention", func() {
+		f, ok := main.ResolveDateFilter("What was the energy consumption on 2022-01-01 compared to June 2023?")
+		Expect(ok).Should(BeTrue())
+		Expect(f.Ranges).Should(HaveLen(2))
+		Expect(f.String()).Should(Equal(`Date = 2022-01-01 ("2022-01-01") or Date in 2023-06-01..2023-06-30 ("June 2023")`))
+	})
+
+	It("ignores questions without dates", func() {
+		_, ok := main.ResolveDateFilter("What is the average power consumption?")
+		Expect(ok).Should(BeFalse())
+	})
+})
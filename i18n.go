@@ -0,0 +1,532 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// Messages katalog pesan per locale. Setiap key harus ada di semua locale
+// dan memakai verb format yang sama (dicek oleh test).
+var Messages = map[string]map[string]string{
+	LangEnglish: {
+		// REPL
+		"repl.banner":          "AI-Powered Smart Home Energy Management System",
+		"repl.hint":            "Enter your query (type 'exit' to quit, ':history' to browse past answers, ':suggest' for example questions, ':explain' to see how the last answer was derived):",
+		"repl.prompt":          "> ",
+		"repl.exit_word":       "exit",
+		"repl.unknown_command": "Unknown command %s",
+		"repl.history_off":     "History is disabled.",
+		"repl.history_empty":   "No history entries found.",
+		"repl.no_answer_yet":   "No answer to give feedback on yet.",
+		"repl.feedback_error":  "Error saving feedback: %v",
+		"repl.feedback_saved":  "Saved as %s example with expected answer %q.",
+		"repl.ask_error":       "Error connecting to AI model: %v",
+		"repl.no_explanation":  "No answer to explain yet.",
+		"repl.reset":           "Conversation context cleared.",
+		"repl.suggest_hint":    "Type a number to ask that question.",
+		"repl.compute_error":   "Error in computed column: %v",
+		"repl.filter_set":      "Filter applied to the next questions (%d of %d rows match).",
+		"repl.filter_cleared":  "Filter cleared.",
+		"repl.filter_error":    "Error in filter: %v",
+		"repl.columns":         "Columns: %s",
+		// :explain
+		"explain.question":        "Question: %s",
+		"explain.translated":      "Sent to model as: %s",
+		"explain.intent":          "Intent: %s",
+		"explain.correction":      "Corrected %q to %q",
+		"explain.no_filter":       "Filter: none",
+		"explain.filter":          "Filter: %s",
+		"explain.all_rows":        "all rows",
+		"explain.tokens":          "Estimated tokens: %d of %d",
+		"explain.pre_aggregated":  "summary of %s",
+		"explain.summary_cell":    "  summary row %d, %s = %s",
+		"explain.rows":            "Rows sent: %d of %d (%s)",
+		"explain.aggregator":      "Aggregator chosen by model: %s",
+		"explain.cell":            "  row %d, %s = %s",
+		"explain.model_answer":    "Model answer: %s",
+		"explain.recomputed":      "Recomputed locally: %s",
+		"explain.mismatch":        "Warning: cells reported by the model differ from the table",
+		"explain.confidence":      "Confidence: %.2f",
+		"explain.route":           "  tried %s: confidence %.2f, accepted=%v %s",
+		"router.low_confidence":   "Answer from %s has confidence %.2f below %.2f, trying next backend",
+		"backend.fallback_remote": "Warning: fallback models %s run on the Huggingface API; tables of questions the ONNX model answers with low confidence leave this machine",
+		// Template laporan jawaban
+		"report.translated":  "Question (EN): %s",
+		"report.normalized":  "Understood as: %s",
+		"report.answer":      "Answer: %s",
+		"report.value":       "Value: %s %s",
+		"report.coordinates": "Coordinates: %v",
+		"report.cells":       "Cells: %v",
+		"report.aggregator":  "Aggregator: %s",
+		"report.history":     "[%s] %s\n  -> %s (%s, %dms)",
+		"report.filter":      "  filter: %s",
+		"report.feedback":    "  feedback: correct=%v %s",
+		"report.error":       "error: %s",
+		// Satuan
+		"unit.energy": "kWh",
+		// Startup di main()
+		"main.env_error":        "Error loading .env file: %v",
+		"main.token_missing":    "HUGGINGFACE_TOKEN not found in .env file",
+		"main.csv_read_error":   "Error reading CSV file: %v",
+		"main.csv_parse_error":  "Error parsing CSV file: %v",
+		"main.history_error":    "Error opening history: %v",
+		"history.prune_error":   "Error applying history retention: %v",
+		"main.server_setup":     "Error setting up server: %v",
+		"main.server_stopped":   "Server stopped: %v",
+		"main.unknown_option":   "Unknown %s %q",
+		"main.command_error":    "Error running %s: %v",
+		"main.locale_error":     "Unsupported locale %q, available: %s",
+		"server.grpc_listening": "gRPC TableQA server listening on %s",
+		"server.http_listening": "HTTP API listening on %s",
+		"server.shutting_down":  "Shutting down, waiting up to %v for in-flight requests",
+		// ConnectAIModel
+		"model.loading":          "Model is currently loading, retrying in %.1f seconds...",
+		"error.model_status":     "failed to connect to AI model, status: %s, response: %s",
+		"error.max_retries":      "max retries reached, failed to connect to AI model",
+		"model.empty_sequential": "AI model returned no answers for the sequential questions",
+		"error.no_backend":       "no backend configured",
+		"error.local_model":      "local model failed",
+		"error.onnx_unavailable": "local ONNX inference is not included in this build, rebuild with -tags onnx",
+		"error.invalid_privacy":  "invalid privacy rule",
+		"privacy.syntax":         "%q must look like Column=rule",
+		"privacy.decimals":       "invalid decimals %q for %s",
+		"privacy.unknown_rule":   "unknown rule %q for %s, use pseudonymize, shift or round[:decimals]",
+		"error.invalid_share":    "invalid shared aggregates",
+		"error.encryption_key":   "invalid or missing encryption key",
+		"error.decrypt":          "cannot decrypt data, wrong key or corrupted file",
+		"encrypt.key_length":     "key must be 32 bytes, got %d",
+		"encrypt.key_format":     "expected 32 bytes as base64 or hex",
+		"encrypt.key_required":   "encrypted data needs %s or %s",
+		"encrypt.file_encrypted": "%s is encrypted, set %s or %s",
+		"encrypt.set_key":        "set %s or %s",
+		"error.invalid_config":   "invalid configuration",
+		"error.overloaded":       "too many questions are waiting for the model, try again later",
+		// Error umum
+		"error.empty_query":            "query is empty",
+		"error.unauthenticated":        "unauthenticated",
+		"error.dataset_not_found":      "dataset not found",
+		"error.permission_denied":      "permission denied",
+		"error.quota_exceeded":         "quota exceeded",
+		"error.quota_rows":             "dataset has %d rows, limit is %d",
+		"error.quota_datasets":         "tenant %s already has %d datasets",
+		"error.invalid_role":           "invalid role",
+		"error.entry_not_found":        "history entry not found",
+		"error.history_disabled":       "history is disabled",
+		"error.expected_required":      "expected value is required when the answer is wrong",
+		"error.feedback_on_error":      "the question failed and has no answer to label",
+		"error.dataset_id":             "dataset id is required",
+		"error.invalid_csv":            "invalid CSV: %v",
+		"error.method_not_allowed":     "method not allowed",
+		"error.invalid_json":           "invalid JSON: %v",
+		"error.invalid_limit":          "invalid limit",
+		"error.invalid_since":          "invalid since, expected RFC3339",
+		"error.invalid_filter":         "invalid filter",
+		"error.filter_no_rows":         "filter matched no rows",
+		"error.table_too_large":        "table is too large for the model",
+		"error.table_too_large_detail": "about %d tokens, limit %d; only %d of %d rows fit, add a filter or a date to the question",
+		// Pipeline, CSV, model, dan autentikasi
+		"pipeline.translate":       "translate query",
+		"pipeline.filter_skipped":  "%s (no rows matched, not applied)",
+		"history.write_error":      "Error writing history: %v",
+		"csv.no_data":              "no data found",
+		"csv.duplicate_column":     "duplicate column %q in CSV header",
+		"model.invalid_coordinate": "invalid coordinate %v in AI model response",
+		"model.logit_count":        "got %d logits for %d tokens",
+		"model.unsupported_input":  "unsupported model input %q",
+		"translate.decode":         "decode translation",
+		"translate.empty":          "empty translation from model",
+		"auth.parse_keys":          "parse %s",
+		"auth.token_expired":       "token expired",
+		"bench.invalid_rows":       "invalid -rows value %q",
+		// Konfigurasi
+		"config.not_a_number":     "%q is not a number",
+		"config.unknown_profile":  "unknown profile %q, available: %s",
+		"config.profile_error":    "profile %q: %v",
+		"config.profile_problems": "profile %q:",
+		"config.usage":            "usage: config show",
+		"config.csv_required":     "csv: path is required",
+		"config.csv_missing":      "csv: file %q does not exist",
+		"config.lang":             "lang: must be one of %s",
+		"config.translator":       "translator: must be lexicon, hf or none, got %q",
+		"config.negative":         "%s: must not be negative",
+		"config.at_least_one":     "%s: must be at least 1",
+		"config.min_confidence":   "model.min_confidence: must be between 0 and 1",
+		"config.model_url":        "model.url: must start with http:// or https://",
+		"config.fake_onnx":        "model: fake and onnx cannot both be set",
+		"config.token_required":   "model.token: required unless model.fake or model.onnx is set (or set HUGGINGFACE_TOKEN)",
+		"config.max_age":          "history.max_age: %q is not a duration like 720h",
+		"config.api_keys_missing": "server.api_keys: file %q does not exist",
+		"config.field_error":      "%s: %v",
+		// Ekspresi, filter, dan kolom turunan
+		"expr.unterminated_column":    "unterminated column name at position %d",
+		"expr.unterminated_string":    "unterminated string at position %d",
+		"expr.unexpected":             "unexpected %q at position %d",
+		"expr.too_long":               "expression is longer than %d characters",
+		"expr.too_deep":               "expression is nested deeper than %d levels",
+		"expr.expected":               "expected %q at position %d",
+		"expr.between_and":            "expected \"and\" after between at position %d",
+		"expr.invalid_number_at":      "invalid number %q at position %d",
+		"expr.invalid_date_at":        "invalid date %q at position %d",
+		"expr.unexpected_end":         "unexpected end of expression",
+		"expr.unknown_function_at":    "unknown function %q at position %d",
+		"expr.unknown_function":       "unknown function",
+		"expr.unknown_expression":     "unknown expression %s",
+		"expr.unknown_operator":       "unknown operator %s",
+		"expr.arity":                  "%s expects %s, got %d",
+		"expr.args":                   "%d argument(s)",
+		"expr.args_range":             "%d to %d arguments",
+		"expr.not_bool":               "not expects bool, got %s",
+		"expr.minus_number":           "- expects number, got %s",
+		"expr.op_bool":                "%s expects bool, got %s",
+		"expr.op_numbers":             "%s expects numbers, got %s and %s",
+		"expr.cannot_compare":         "cannot compare %s with %s",
+		"expr.expects_number":         "expects number, got %s",
+		"expr.expects_string":         "expects string, got %s",
+		"expr.expects_string_or_date": "expects string or date, got %s",
+		"expr.condition_bool":         "condition must be bool, got %s",
+		"expr.division_by_zero":       "division by zero",
+		"expr.invalid_date":           "invalid date %q",
+		"expr.invalid_time":           "invalid time %q",
+		"expr.computed_syntax":        "computed column must look like \"Name = expression\"",
+		"filter.not_condition":        "filter must be a condition, got %s",
+		// Operasi tabel
+		"table.unknown_column":        "unknown column %q",
+		"table.unknown_lookup_column": "unknown lookup column %q",
+		"table.column_exists":         "column %q already exists",
+		"table.duplicate_column":      "duplicate column %q",
+		"table.column_in_both":        "column %q exists in both tables",
+		"table.lookup_not_unique":     "lookup key %q is not unique",
+		"table.pivot_clash":           "pivot column %q clashes with the row key",
+		"table.aggregation_syntax":    "aggregation must look like \"sum(Column)\", got %q",
+		"table.unknown_aggregation":   "unknown aggregation %q",
+		"table.needs_column":          "%s needs a column",
+		"table.needs_number":          "%s needs a number column, %q is %s",
+		"table.ragged":                "column %q has %d rows, expected %d",
+		"table.row":                   "row %d",
+		"table.at_row":                "%s, row %d",
+		// Dispatcher
+		"dispatcher.limit_syntax":      "%q must be backend=limit",
+		"dispatcher.limit_value":       "%q: limit must be a number of at least 1",
+		"dispatcher.overloaded_detail": "%d waiting, %d running on %s",
+		// Laporan eval
+		"eval.backend":         "Backend: %s",
+		"eval.intent":          "intent",
+		"eval.total":           "total",
+		"eval.correct":         "correct",
+		"eval.errors":          "errors",
+		"eval.accuracy":        "accuracy",
+		"eval.overall":         "overall",
+		"eval.dataset_missing": "no table for dataset %q, pass it to eval with -dataset %s=<csv>",
+		"eval.dataset_syntax":  "-dataset must look like id=file.csv, got %q",
+		// Agregat bersama
+		"share.epsilon_clip":        "epsilon and clip must be positive",
+		"share.unsupported_version": "unsupported version %d",
+		"share.usage":               "usage: share export|compare [flags]",
+		"share.unknown_command":     "unknown share command %q",
+		"share.month":               "month",
+		"share.category":            "category",
+		"share.local":               "local",
+		"share.average":             "average",
+		"share.homes":               "homes",
+		"share.diff":                "diff",
+	},
+	LangIndonesian: {
+		"repl.banner":          "Sistem Manajemen Energi Rumah Pintar Berbasis AI",
+		"repl.hint":            "Masukkan pertanyaan (ketik 'keluar' untuk berhenti, ':history' untuk melihat jawaban sebelumnya, ':suggest' untuk contoh pertanyaan, ':explain' untuk melihat asal jawaban terakhir):",
+		"repl.prompt":          "> ",
+		"repl.exit_word":       "keluar",
+		"repl.unknown_command": "Perintah %s tidak dikenal",
+		"repl.history_off":     "History tidak aktif.",
+		"repl.history_empty":   "Tidak ada history yang cocok.",
+		"repl.no_answer_yet":   "Belum ada jawaban untuk dinilai.",
+		"repl.feedback_error":  "Gagal menyimpan feedback: %v",
+		"repl.feedback_saved":  "Disimpan sebagai contoh %s dengan jawaban benar %q.",
+		"repl.ask_error":       "Gagal menghubungi model AI: %v",
+		"repl.no_explanation":  "Belum ada jawaban untuk dijelaskan.",
+		"repl.reset":           "Konteks percakapan dihapus.",
+		"repl.suggest_hint":    "Ketik nomornya untuk mengajukan pertanyaan tersebut.",
+		"repl.compute_error":   "Kolom turunan tidak valid: %v",
+		"repl.filter_set":      "Filter dipakai untuk pertanyaan berikutnya (%d dari %d baris cocok).",
+		"repl.filter_cleared":  "Filter dihapus.",
+		"repl.filter_error":    "Filter tidak valid: %v",
+		"repl.columns":         "Kolom: %s",
+
+		"explain.question":        "Pertanyaan: %s",
+		"explain.translated":      "Dikirim ke model sebagai: %s",
+		"explain.intent":          "Intent: %s",
+		"explain.correction":      "%q dikoreksi menjadi %q",
+		"explain.no_filter":       "Filter: tidak ada",
+		"explain.filter":          "Filter: %s",
+		"explain.all_rows":        "semua baris",
+		"explain.tokens":          "Perkiraan token: %d dari %d",
+		"explain.pre_aggregated":  "ringkasan %s",
+		"explain.summary_cell":    "  baris ringkasan %d, %s = %s",
+		"explain.rows":            "Baris dikirim: %d dari %d (%s)",
+		"explain.aggregator":      "Agregator pilihan model: %s",
+		"explain.cell":            "  baris %d, %s = %s",
+		"explain.model_answer":    "Jawaban model: %s",
+		"explain.recomputed":      "Hitung ulang lokal: %s",
+		"explain.mismatch":        "Peringatan: cell dari model berbeda dengan isi tabel",
+		"explain.confidence":      "Keyakinan: %.2f",
+		"explain.route":           "  mencoba %s: keyakinan %.2f, diterima=%v %s",
+		"router.low_confidence":   "Jawaban dari %s punya keyakinan %.2f di bawah %.2f, mencoba backend berikutnya",
+		"backend.fallback_remote": "Peringatan: model cadangan %s berjalan di API Huggingface; tabel pertanyaan yang dijawab model ONNX dengan keyakinan rendah keluar dari mesin ini",
+
+		"report.translated":  "Pertanyaan (EN): %s",
+		"report.normalized":  "Dipahami sebagai: %s",
+		"report.answer":      "Jawaban: %s",
+		"report.value":       "Nilai: %s %s",
+		"report.coordinates": "Koordinat: %v",
+		"report.cells":       "Sel: %v",
+		"report.aggregator":  "Agregator: %s",
+		"report.history":     "[%s] %s\n  -> %s (%s, %dms)",
+		"report.filter":      "  filter: %s",
+		"report.feedback":    "  feedback: benar=%v %s",
+		"report.error":       "galat: %s",
+
+		"unit.energy": "kWh",
+
+		"main.env_error":        "Gagal memuat file .env: %v",
+		"main.token_missing":    "HUGGINGFACE_TOKEN tidak ditemukan di file .env",
+		"main.csv_read_error":   "Gagal membaca file CSV: %v",
+		"main.csv_parse_error":  "Gagal mem-parsing file CSV: %v",
+		"main.history_error":    "Gagal membuka history: %v",
+		"history.prune_error":   "Gagal menerapkan retention history: %v",
+		"main.server_setup":     "Gagal menyiapkan server: %v",
+		"main.server_stopped":   "Server berhenti: %v",
+		"main.unknown_option":   "%s %q tidak dikenal",
+		"main.command_error":    "Gagal menjalankan %s: %v",
+		"main.locale_error":     "Locale %q tidak didukung, pilihan: %s",
+		"server.grpc_listening": "Server gRPC TableQA berjalan di %s",
+		"server.http_listening": "HTTP API berjalan di %s",
+		"server.shutting_down":  "Mematikan server, menunggu request yang berjalan paling lama %v",
+
+		"model.loading":          "Model sedang dimuat, mencoba lagi dalam %.1f detik...",
+		"error.model_status":     "gagal terhubung ke model AI, status: %s, response: %s",
+		"error.max_retries":      "batas percobaan tercapai, gagal terhubung ke model AI",
+		"model.empty_sequential": "model AI tidak mengembalikan jawaban untuk pertanyaan berurutan",
+		"error.no_backend":       "tidak ada backend yang dikonfigurasi",
+		"error.local_model":      "model lokal gagal dijalankan",
+		"error.onnx_unavailable": "inferensi ONNX lokal tidak ada di build ini, build ulang dengan -tags onnx",
+		"error.invalid_privacy":  "aturan privasi tidak valid",
+		"privacy.syntax":         "%q harus berbentuk Kolom=aturan",
+		"privacy.decimals":       "jumlah desimal %q untuk %s tidak valid",
+		"privacy.unknown_rule":   "aturan %q untuk %s tidak dikenal, pakai pseudonymize, shift, atau round[:desimal]",
+		"error.invalid_share":    "agregat bersama tidak valid",
+		"error.encryption_key":   "kunci enkripsi tidak valid atau tidak ada",
+		"error.decrypt":          "data tidak bisa didekripsi, kunci salah atau file rusak",
+		"encrypt.key_length":     "kunci harus 32 byte, bukan %d",
+		"encrypt.key_format":     "harus 32 byte dalam bentuk base64 atau hex",
+		"encrypt.key_required":   "data terenkripsi butuh %s atau %s",
+		"encrypt.file_encrypted": "%s terenkripsi, isi %s atau %s",
+		"encrypt.set_key":        "isi %s atau %s",
+		"error.invalid_config":   "konfigurasi tidak valid",
+		"error.overloaded":       "terlalu banyak pertanyaan menunggu model, coba lagi nanti",
+
+		"error.empty_query":            "pertanyaan kosong",
+		"error.unauthenticated":        "belum terautentikasi",
+		"error.dataset_not_found":      "dataset tidak ditemukan",
+		"error.permission_denied":      "akses ditolak",
+		"error.quota_exceeded":         "quota terlampaui",
+		"error.quota_rows":             "dataset berisi %d baris, batasnya %d",
+		"error.quota_datasets":         "tenant %s sudah punya %d dataset",
+		"error.invalid_role":           "role tidak valid",
+		"error.entry_not_found":        "entry history tidak ditemukan",
+		"error.history_disabled":       "history tidak aktif",
+		"error.expected_required":      "jawaban yang benar wajib diisi jika jawaban salah",
+		"error.feedback_on_error":      "pertanyaan gagal dijawab sehingga tidak ada jawaban untuk diberi label",
+		"error.dataset_id":             "id dataset wajib diisi",
+		"error.invalid_csv":            "CSV tidak valid: %v",
+		"error.method_not_allowed":     "method tidak diizinkan",
+		"error.invalid_json":           "JSON tidak valid: %v",
+		"error.invalid_limit":          "limit tidak valid",
+		"error.invalid_since":          "since tidak valid, gunakan format RFC3339",
+		"error.invalid_filter":         "filter tidak valid",
+		"error.filter_no_rows":         "tidak ada baris yang cocok dengan filter",
+		"error.table_too_large":        "tabel terlalu besar untuk model",
+		"error.table_too_large_detail": "sekitar %d token, batas %d; hanya %d dari %d baris yang muat, tambahkan filter atau tanggal di pertanyaan",
+
+		"pipeline.translate":       "terjemahan pertanyaan",
+		"pipeline.filter_skipped":  "%s (tidak ada baris yang cocok, tidak diterapkan)",
+		"history.write_error":      "Gagal menulis history: %v",
+		"csv.no_data":              "tidak ada data",
+		"csv.duplicate_column":     "kolom %q muncul dua kali di header CSV",
+		"model.invalid_coordinate": "koordinat %v di response model AI tidak valid",
+		"model.logit_count":        "menerima %d logit untuk %d token",
+		"model.unsupported_input":  "input model %q tidak didukung",
+		"translate.decode":         "membaca hasil terjemahan",
+		"translate.empty":          "model tidak mengembalikan terjemahan",
+		"auth.parse_keys":          "membaca %s",
+		"auth.token_expired":       "token kedaluwarsa",
+		"bench.invalid_rows":       "nilai -rows %q tidak valid",
+
+		"config.not_a_number":     "%q bukan angka",
+		"config.unknown_profile":  "profil %q tidak dikenal, yang tersedia: %s",
+		"config.profile_error":    "profil %q: %v",
+		"config.profile_problems": "profil %q:",
+		"config.usage":            "pemakaian: config show",
+		"config.csv_required":     "csv: path wajib diisi",
+		"config.csv_missing":      "csv: file %q tidak ada",
+		"config.lang":             "lang: harus salah satu dari %s",
+		"config.translator":       "translator: harus lexicon, hf, atau none, bukan %q",
+		"config.negative":         "%s: tidak boleh negatif",
+		"config.at_least_one":     "%s: minimal 1",
+		"config.min_confidence":   "model.min_confidence: harus di antara 0 dan 1",
+		"config.model_url":        "model.url: harus diawali http:// atau https://",
+		"config.fake_onnx":        "model: fake dan onnx tidak boleh diisi bersamaan",
+		"config.token_required":   "model.token: wajib diisi kecuali model.fake atau model.onnx diisi (atau isi HUGGINGFACE_TOKEN)",
+		"config.max_age":          "history.max_age: %q bukan durasi seperti 720h",
+		"config.api_keys_missing": "server.api_keys: file %q tidak ada",
+		"config.field_error":      "%s: %v",
+
+		"expr.unterminated_column":    "nama kolom tidak ditutup di posisi %d",
+		"expr.unterminated_string":    "string tidak ditutup di posisi %d",
+		"expr.unexpected":             "%q tidak terduga di posisi %d",
+		"expr.too_long":               "ekspresi lebih panjang dari %d karakter",
+		"expr.too_deep":               "ekspresi bersarang lebih dari %d tingkat",
+		"expr.expected":               "seharusnya %q di posisi %d",
+		"expr.between_and":            "seharusnya \"and\" setelah between di posisi %d",
+		"expr.invalid_number_at":      "angka %q tidak valid di posisi %d",
+		"expr.invalid_date_at":        "tanggal %q tidak valid di posisi %d",
+		"expr.unexpected_end":         "ekspresi berakhir terlalu cepat",
+		"expr.unknown_function_at":    "fungsi %q tidak dikenal di posisi %d",
+		"expr.unknown_function":       "fungsi tidak dikenal",
+		"expr.unknown_expression":     "ekspresi %s tidak dikenal",
+		"expr.unknown_operator":       "operator %s tidak dikenal",
+		"expr.arity":                  "%s membutuhkan %s, diberi %d",
+		"expr.args":                   "%d argumen",
+		"expr.args_range":             "%d sampai %d argumen",
+		"expr.not_bool":               "not membutuhkan bool, diberi %s",
+		"expr.minus_number":           "- membutuhkan angka, diberi %s",
+		"expr.op_bool":                "%s membutuhkan bool, diberi %s",
+		"expr.op_numbers":             "%s membutuhkan angka, diberi %s dan %s",
+		"expr.cannot_compare":         "%s tidak bisa dibandingkan dengan %s",
+		"expr.expects_number":         "membutuhkan angka, diberi %s",
+		"expr.expects_string":         "membutuhkan string, diberi %s",
+		"expr.expects_string_or_date": "membutuhkan string atau tanggal, diberi %s",
+		"expr.condition_bool":         "kondisi harus bool, diberi %s",
+		"expr.division_by_zero":       "pembagian dengan nol",
+		"expr.invalid_date":           "tanggal %q tidak valid",
+		"expr.invalid_time":           "jam %q tidak valid",
+		"expr.computed_syntax":        "kolom turunan harus berbentuk \"Nama = ekspresi\"",
+		"filter.not_condition":        "filter harus berupa kondisi, diberi %s",
+
+		"table.unknown_column":        "kolom %q tidak dikenal",
+		"table.unknown_lookup_column": "kolom lookup %q tidak dikenal",
+		"table.column_exists":         "kolom %q sudah ada",
+		"table.duplicate_column":      "kolom %q ganda",
+		"table.column_in_both":        "kolom %q ada di kedua tabel",
+		"table.lookup_not_unique":     "kunci lookup %q tidak unik",
+		"table.pivot_clash":           "kolom pivot %q bentrok dengan kunci baris",
+		"table.aggregation_syntax":    "agregasi harus berbentuk \"sum(Kolom)\", diberi %q",
+		"table.unknown_aggregation":   "agregasi %q tidak dikenal",
+		"table.needs_column":          "%s membutuhkan kolom",
+		"table.needs_number":          "%s membutuhkan kolom angka, %q bertipe %s",
+		"table.ragged":                "kolom %q berisi %d baris, seharusnya %d",
+		"table.row":                   "baris %d",
+		"table.at_row":                "%s, baris %d",
+
+		"dispatcher.limit_syntax":      "%q harus berbentuk backend=batas",
+		"dispatcher.limit_value":       "%q: batas harus angka minimal 1",
+		"dispatcher.overloaded_detail": "%d menunggu, %d berjalan di %s",
+
+		"eval.backend":         "Backend: %s",
+		"eval.intent":          "intent",
+		"eval.total":           "total",
+		"eval.correct":         "benar",
+		"eval.errors":          "galat",
+		"eval.accuracy":        "akurasi",
+		"eval.overall":         "semua",
+		"eval.dataset_missing": "tidak ada tabel untuk dataset %q, berikan ke eval dengan -dataset %s=<csv>",
+		"eval.dataset_syntax":  "-dataset harus berbentuk id=file.csv, bukan %q",
+
+		"share.epsilon_clip":        "epsilon dan clip harus positif",
+		"share.unsupported_version": "versi %d tidak didukung",
+		"share.usage":               "pemakaian: share export|compare [flag]",
+		"share.unknown_command":     "perintah share %q tidak dikenal",
+		"share.month":               "bulan",
+		"share.category":            "kategori",
+		"share.local":               "lokal",
+		"share.average":             "rata-rata",
+		"share.homes":               "rumah",
+		"share.diff":                "selisih",
+	},
+}
+
+var currentLocale atomic.Value
+
+func init() {
+	currentLocale.Store(LangEnglish)
+}
+
+// Locales mengembalikan daftar locale yang tersedia
+func Locales() []string {
+	locales := make([]string, 0, len(Messages))
+	for l := range Messages {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ResolveLocale memilih locale dari nilai flag, lalu APP_LOCALE, lalu LANG (misalnya "id_ID.UTF-8")
+func ResolveLocale(flagValue string) string {
+	for _, v := range []string{flagValue, os.Getenv("APP_LOCALE"), os.Getenv("LANG")} {
+		if v == "" {
+			continue
+		}
+		lang := strings.ToLower(strings.FieldsFunc(v, func(r rune) bool { return r == '_' || r == '-' || r == '.' })[0])
+		if _, ok := Messages[lang]; ok {
+			return lang
+		}
+		if v == flagValue {
+			return v
+		}
+	}
+	return LangEnglish
+}
+
+// SetLocale mengganti locale untuk semua pesan CLI dan server
+func SetLocale(locale string) error {
+	if _, ok := Messages[locale]; !ok {
+		return errors.New(Tl(LangEnglish, "main.locale_error", locale, strings.Join(Locales(), ", ")))
+	}
+	currentLocale.Store(locale)
+	return nil
+}
+
+// CurrentLocale mengembalikan locale yang sedang aktif
+func CurrentLocale() string {
+	return currentLocale.Load().(string)
+}
+
+// T menerjemahkan key dengan locale aktif
+func T(key string, args ...interface{}) string {
+	return Tl(CurrentLocale(), key, args...)
+}
+
+// Tl menerjemahkan key dengan locale tertentu, jatuh ke bahasa Inggris jika key belum ada
+func Tl(locale, key string, args ...interface{}) string {
+	format, ok := Messages[locale][key]
+	if !ok {
+		format, ok = Messages[LangEnglish][key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// messageError error sentinel yang pesannya diambil dari katalog saat ditampilkan
+type messageError string
+
+func (e messageError) Error() string {
+	return T(string(e))
+}
@@ -0,0 +1,119 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	// catalogKey key yang dipakai lewat T, Tl, atau messageError di kode
+	catalogKey = regexp.MustCompile(`\b(?:T|messageError)\("([a-z_]+\.[a-z_.]+)"|\bTl\([^,()]+, "([a-z_]+\.[a-z_.]+)"`)
+	// literalError pesan error atau log bahasa Inggris yang ditulis langsung, bukan dari katalog,
+	// termasuk setelah awalan seperti "%w: "
+	literalError = regexp.MustCompile(`(?:fmt\.Errorf|errors\.New|log\.(?:Print|Fatal)(?:f|ln)?)\("(?:%[a-z]: )*[A-Za-z]`)
+)
+
+var _ = Describe("Message catalog", func() {
+	AfterEach(func() {
+		Expect(main.SetLocale(main.LangEnglish)).Should(Succeed())
+	})
+
+	It("has every key in every locale with the same format verbs", func() {
+		english := main.Messages[main.LangEnglish]
+		for _, locale := range main.Locales() {
+			messages := main.Messages[locale]
+			Expect(messages).Should(HaveLen(len(english)), "locale %s", locale)
+			for key, format := range english {
+				Expect(messages).Should(HaveKey(key), "locale %s", locale)
+				Expect(formatVerb.FindAllString(messages[key], -1)).Should(Equal(formatVerb.FindAllString(format, -1)), "locale %s key %s", locale, key)
+			}
+		}
+	})
+
+	It("has every key used in the code and no hard-coded user-facing errors", func() {
+		files, err := filepath.Glob("*.go")
+		Expect(err).ShouldNot(HaveOccurred())
+		used := 0
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			src, err := ioutil.ReadFile(file)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, m := range catalogKey.FindAllStringSubmatch(string(src), -1) {
+				key := m[1] + m[2]
+				Expect(main.Messages[main.LangEnglish]).Should(HaveKey(key), "%s uses %s", file, key)
+				used++
+			}
+			// Semua error dan log bisa sampai ke user lewat REPL, API, laporan, atau startup. Stub
+			// hasil generate di tableqapb tidak ikut diperiksa karena tidak ada di direktori ini.
+			Expect(literalError.FindAllString(string(src), -1)).Should(BeEmpty(), file)
+		}
+		Expect(used).Should(BeNumerically(">", 100))
+	})
+
+	It("localizes expression, table and report messages", func() {
+		Expect(main.SetLocale(main.LangIndonesian)).Should(Succeed())
+		_, _, err := main.ApplyFilter(map[string][]string{"Room": {"Kitchen"}}, "Voltage > 1")
+		Expect(err).Should(MatchError(main.ErrInvalidFilter))
+		Expect(err.Error()).Should(Equal(`filter tidak valid: kolom "Voltage" tidak dikenal`))
+		_, err = main.ParseBackendLimits("hf")
+		Expect(err).Should(MatchError(`"hf" harus berbentuk backend=batas`))
+		cfg := main.Config{Profile: "home", CSV: "data-series.csv", Translator: "google"}
+		err = cfg.Validate()
+		Expect(err).Should(MatchError(main.ErrInvalidConfig))
+		Expect(err.Error()).Should(ContainSubstring(`translator: harus lexicon, hf, atau none, bukan "google"`))
+		Expect(err.Error()).Should(ContainSubstring("model.max_retries: minimal 1"))
+		_, err = main.ParsePrivacy("Room=hide")
+		Expect(err).Should(MatchError(main.ErrInvalidPrivacy))
+		Expect(err.Error()).Should(Equal(`aturan privasi tidak valid: aturan "hide" untuk Room tidak dikenal, pakai pseudonymize, shift, atau round[:desimal]`))
+		_, err = main.NewCipher([]byte("short"))
+		Expect(err).Should(MatchError(main.ErrEncryptionKey))
+		Expect(err.Error()).Should(Equal("kunci enkripsi tidak valid atau tidak ada: kunci harus 32 byte, bukan 5"))
+		_, err = main.CsvToSlice("Room,Room\nKitchen,Garage")
+		Expect(err).Should(MatchError(`kolom "Room" muncul dua kali di header CSV`))
+
+		var out strings.Builder
+		main.PrintEvalReport(&out, main.EvalReport{Backend: "fake"})
+		Expect(out.String()).Should(ContainSubstring("akurasi"))
+		out.Reset()
+		main.PrintShareComparison(&out, nil)
+		Expect(out.String()).Should(ContainSubstring("kategori"))
+	})
+
+	It("resolves the locale from flag, APP_LOCALE and LANG", func() {
+		GinkgoT().Setenv("APP_LOCALE", "")
+		GinkgoT().Setenv("LANG", "id_ID.UTF-8")
+		Expect(main.ResolveLocale("")).Should(Equal(main.LangIndonesian))
+		Expect(main.ResolveLocale("en")).Should(Equal(main.LangEnglish))
+		GinkgoT().Setenv("LANG", "C")
+		Expect(main.ResolveLocale("")).Should(Equal(main.LangEnglish))
+		Expect(main.SetLocale(main.ResolveLocale("fr"))).ShouldNot(Succeed())
+	})
+
+	It("localizes sentinel errors without breaking errors.Is", func() {
+		Expect(main.ErrEmptyQuery.Error()).Should(Equal("query is empty"))
+		Expect(main.SetLocale(main.LangIndonesian)).Should(Succeed())
+		Expect(main.ErrEmptyQuery.Error()).Should(Equal("pertanyaan kosong"))
+		Expect((&main.ModelError{Status: "500", Body: "x"}).Error()).Should(ContainSubstring("gagal terhubung"))
+		Expect(main.T("missing.key")).Should(Equal("missing.key"))
+	})
+
+	It("adds a unit when all cells come from the energy column", func() {
+		table := map[string][]string{"Appliance": {"TV", "Lights"}, "Energy_Consumption": {"1.2", "0.3"}}
+		resp := main.Response{Coordinates: [][]int{{0, 1}, {1, 1}}, Aggregator: "SUM"}
+		Expect(main.AnswerUnit(table, resp, main.LangEnglish)).Should(Equal("kWh"))
+		resp.Aggregator = "COUNT"
+		Expect(main.AnswerUnit(table, resp, main.LangEnglish)).Should(BeEmpty())
+		resp = main.Response{Coordinates: [][]int{{0, 0}}, Aggregator: "NONE"}
+		Expect(main.AnswerUnit(table, resp, main.LangEnglish)).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,42 @@
+package main
+
+import "strings"
+
+// Intent jenis pertanyaan, dipakai untuk mengelompokkan hasil evaluasi
+const (
+	IntentAverage = "average"
+	IntentSum     = "sum"
+	IntentMax     = "max"
+	IntentMin     = "min"
+	IntentCount   = "count"
+	IntentCompare = "compare"
+	IntentTrend   = "trend"
+	IntentLookup  = "lookup"
+)
+
+// intentKeywords urutan penting: kata yang lebih spesifik dicek lebih dulu
+var intentKeywords = []struct {
+	intent   string
+	keywords []string
+}{
+	{IntentCompare, []string{"compare", "comparison", "versus", " vs ", "difference between"}},
+	{IntentTrend, []string{"trend", "over the past", "over time", "change"}},
+	{IntentAverage, []string{"average", "mean", "avg"}},
+	{IntentCount, []string{"how many", "count", "number of"}},
+	{IntentMax, []string{"maximum", "highest", "max", "peak", "most"}},
+	{IntentMin, []string{"minimum", "lowest", "min", "least"}},
+	{IntentSum, []string{"total", "sum", "how much", "overall"}},
+}
+
+// ClassifyIntent menebak jenis pertanyaan dari kata kuncinya
+func ClassifyIntent(query string) string {
+	q := " " + strings.ToLower(query) + " "
+	for _, ik := range intentKeywords {
+		for _, kw := range ik.keywords {
+			if strings.Contains(q, kw) {
+				return ik.intent
+			}
+		}
+	}
+	return IntentLookup
+}
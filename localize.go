@@ -0,0 +1,123 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var indonesianMonths = []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatNumber menampilkan angka sesuai bahasa: "1234.5" (en) atau "1.234,5" (id).
+// Bilangan bulat empat digit tidak dikelompokkan agar tahun tetap terbaca "2023".
+func FormatNumber(v float64, lang string) string {
+	s := formatNumber(v)
+	if lang != LangIndonesian {
+		return s
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if len(intPart) > 4 || (hasFrac && len(intPart) > 3) {
+		var grouped []string
+		for len(intPart) > 3 {
+			grouped = append([]string{intPart[len(intPart)-3:]}, grouped...)
+			intPart = intPart[:len(intPart)-3]
+		}
+		intPart = strings.Join(append([]string{intPart}, grouped...), ".")
+	}
+	if hasFrac {
+		return sign + intPart + "," + frac
+	}
+	return sign + intPart
+}
+
+// FormatDate menampilkan tanggal sesuai bahasa: "2023-06-01" (en) atau "1 Juni 2023" (id)
+func FormatDate(t time.Time, lang string) string {
+	if lang != LangIndonesian {
+		return t.Format(dateLayout)
+	}
+	return strconv.Itoa(t.Day()) + " " + indonesianMonths[t.Month()] + " " + strconv.Itoa(t.Year())
+}
+
+// Jam seperti "17:00" ikut dicocokkan agar tidak dianggap dua angka, lalu dibiarkan apa adanya
+var localizablePattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b|\b\d{1,2}:\d{2}\b|-?\b\d+(?:\.\d+)?\b`)
+
+// LocalizeText mengganti semua tanggal ISO dan angka di dalam teks sesuai bahasa
+func LocalizeText(text, lang string) string {
+	if lang != LangIndonesian {
+		return text
+	}
+	return localizablePattern.ReplaceAllStringFunc(text, func(m string) string {
+		if strings.Contains(m, ":") {
+			return m
+		}
+		if t, err := time.Parse(dateLayout, m); err == nil {
+			return FormatDate(t, lang)
+		}
+		v, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return m
+		}
+		return FormatNumber(v, lang)
+	})
+}
+
+var indonesianAggregators = map[string]string{
+	"SUM":     "Jumlah",
+	"AVERAGE": "Rata-rata",
+	"COUNT":   "Banyaknya",
+}
+
+// FormatAnswer menyusun jawaban untuk user. Untuk bahasa Inggris jawaban model dipakai apa adanya,
+// untuk bahasa Indonesia nilai agregasi dihitung lalu angka dan tanggalnya dilokalkan.
+func FormatAnswer(resp Response, lang string) string {
+	if lang != LangIndonesian {
+		return resp.Answer
+	}
+	value, ok := ComputeAnswer(resp)
+	if !ok {
+		return LocalizeText(resp.Answer, lang)
+	}
+	value = LocalizeText(value, lang)
+	if label, ok := indonesianAggregators[strings.ToUpper(resp.Aggregator)]; ok {
+		return label + ": " + value
+	}
+	return value
+}
+
+// columnUnits key katalog satuan untuk kolom yang namanya mengandung kata tertentu
+var columnUnits = map[string]string{
+	"energy": "unit.energy",
+}
+
+// AnswerUnit mengembalikan satuan jawaban jika semua cell yang dipilih berasal dari kolom yang
+// satuannya diketahui. COUNT tidak bersatuan karena hasilnya banyaknya cell.
+func AnswerUnit(table map[string][]string, resp Response, lang string) string {
+	if len(resp.Coordinates) == 0 || strings.EqualFold(resp.Aggregator, "COUNT") {
+		return ""
+	}
+	columns := sortedColumns(table)
+	unit := ""
+	for _, coord := range resp.Coordinates {
+		if len(coord) != 2 || coord[1] >= len(columns) {
+			return ""
+		}
+		key := ""
+		for word, k := range columnUnits {
+			if strings.Contains(strings.ToLower(columns[coord[1]]), word) {
+				key = k
+			}
+		}
+		if key == "" || unit != "" && unit != key {
+			return ""
+		}
+		unit = key
+	}
+	return Tl(lang, unit)
+}
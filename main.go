@@ -1,31 +1,47 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"flag"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
-// AIModelConnector struct untuk menyimpan http.Client
+// AIModelConnector struct untuk menyimpan http.Client. Aman dipakai bersamaan dari banyak goroutine:
+// ConnectAIModel tidak mengubah field apa pun dan http.Client sendiri aman untuk concurrency, asalkan
+// field tidak diubah setelah connector mulai dipakai. Batas jumlah pemanggilan bersamaan diatur oleh
+// Dispatcher, bukan oleh connector.
 type AIModelConnector struct {
+	// Client http.Client yang dipakai bersama, nil berarti http.DefaultClient
 	Client *http.Client
+	// URL endpoint model, kosong berarti DefaultModelURL
+	URL string
+	// MaxRetries jumlah percobaan selama model masih loading, 0 berarti DefaultMaxRetries
+	MaxRetries int
 }
 
 // Inputs struct untuk mendefinisikan format input untuk AI model
 type Inputs struct {
 	Table map[string][]string `json:"table"`
 	Query string              `json:"query"`
+	// PrevQueries pertanyaan sebelumnya dalam percakapan SQA. Endpoint Huggingface menerimanya
+	// sebagai daftar query dengan parameters.sequential, lihat newSequentialRequest.
+	PrevQueries []string `json:"-"`
+	// PrevCoordinates koordinat jawaban sebelumnya, hanya dipakai TAPAS lokal sebagai prev_labels;
+	// endpoint Huggingface tidak punya field ini
+	PrevCoordinates [][]int `json:"-"`
 }
 
 // Response struct untuk mendefinisikan format response dari AI model
@@ -34,6 +50,10 @@ type Response struct {
 	Coordinates [][]int  `json:"coordinates"`
 	Cells       []string `json:"cells"`
 	Aggregator  string   `json:"aggregator"`
+	// Probabilitas hanya diisi oleh model ONNX lokal; endpoint Huggingface tidak mengembalikannya.
+	// CellProbabilities sejajar dengan Coordinates.
+	CellProbabilities       []float64          `json:"cell_probabilities,omitempty"`
+	AggregatorProbabilities map[string]float64 `json:"aggregator_probabilities,omitempty"`
 }
 
 // CsvToSlice fungsi untuk mengonversi CSV menjadi map
@@ -45,12 +65,21 @@ func CsvToSlice(data string) (map[string][]string, error) {
 	}
 
 	if len(records) < 1 {
-		return nil, errors.New("no data found")
+		return nil, errors.New(T("csv.no_data"))
 	}
 
 	header := records[0]
 	result := make(map[string][]string)
 
+	// Kolom dengan nama sama akan saling menimpa di map, jadi ditolak
+	for i, col := range header {
+		for _, other := range header[:i] {
+			if col == other {
+				return nil, errors.New(T("csv.duplicate_column", col))
+			}
+		}
+	}
+
 	for i, col := range header {
 		result[col] = make([]string, 0, len(records)-1)
 		for _, record := range records[1:] {
@@ -63,121 +92,306 @@ func CsvToSlice(data string) (map[string][]string, error) {
 	return result, nil
 }
 
-// ConnectAIModel fungsi untuk menghubungkan ke AI model dan mendapatkan response
-func (c *AIModelConnector) ConnectAIModel(payload Inputs, token string) (Response, error) {
-	url := "https://api-inference.huggingface.co/models/google/tapas-base-finetuned-wtq"
-	data, err := json.Marshal(payload) // Konversi payload ke JSON
-	if err != nil {
+// DecodeResponse fungsi untuk mengubah body JSON dari AI model menjadi Response
+func DecodeResponse(data []byte) (Response, error) {
+	var aiResponse Response
+	if err := json.Unmarshal(data, &aiResponse); err != nil {
 		return Response{}, err
 	}
+	// Setiap koordinat harus berupa pasangan [row, column] yang tidak negatif
+	for _, coord := range aiResponse.Coordinates {
+		if len(coord) != 2 || coord[0] < 0 || coord[1] < 0 {
+			return Response{}, errors.New(T("model.invalid_coordinate", coord))
+		}
+	}
+	return aiResponse, nil
+}
+
+// ConnectAIModel fungsi untuk menghubungkan ke AI model dan mendapatkan response
+func (c *AIModelConnector) ConnectAIModel(payload Inputs, token string) (Response, error) {
+	return c.ConnectAIModelContext(context.Background(), payload, token)
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+// ConnectAIModelContext sama seperti ConnectAIModel tetapi bisa dibatalkan lewat ctx
+func (c *AIModelConnector) ConnectAIModelContext(ctx context.Context, payload Inputs, token string) (Response, error) {
+	url := DefaultModelURL
+	if c.URL != "" {
+		url = c.URL
+	}
+	var body interface{} = payload
+	sequential := len(payload.PrevQueries) > 0
+	if sequential {
+		body = newSequentialRequest(payload)
+	}
+	data, err := json.Marshal(body) // Konversi payload ke JSON
 	if err != nil {
 		return Response{}, err
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
 
 	// Retry logic untuk mencoba kembali koneksi ke model AI jika gagal
-	maxRetries := 10
+	maxRetries := c.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
 	for i := 0; i < maxRetries; i++ {
-		resp, err := c.Client.Do(req)
+		// Request dibuat ulang setiap percobaan karena body sudah terbaca
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+		if err != nil {
+			return Response{}, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+
+		client := c.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+		resp, err := client.Do(req)
 		if err != nil {
 			return Response{}, err
 		}
-		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 
 		if resp.StatusCode == http.StatusOK {
-			var aiResponse Response
-			if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
-				return Response{}, err
+			if sequential {
+				return decodeSequentialResponse(body)
 			}
-			return aiResponse, nil
+			return DecodeResponse(body)
 		}
 
 		if resp.StatusCode == http.StatusServiceUnavailable {
 			var result map[string]interface{}
-			body, _ := ioutil.ReadAll(resp.Body)
 			if err := json.Unmarshal(body, &result); err == nil {
 				if estimatedTime, ok := result["estimated_time"].(float64); ok {
-					log.Printf("Model is currently loading, retrying in %.1f seconds...\n", estimatedTime)
-					time.Sleep(time.Duration(estimatedTime) * time.Second)
+					wait := time.Duration(estimatedTime) * time.Second
+					log.Println(T("model.loading", estimatedTime))
+					if progress := ProgressFromContext(ctx); progress != nil {
+						progress(i+1, wait)
+					}
+					select {
+					case <-time.After(wait):
+					case <-ctx.Done():
+						return Response{}, ctx.Err()
+					}
 					continue
 				}
 			}
 		}
 
-		body, _ := ioutil.ReadAll(resp.Body)
-		return Response{}, fmt.Errorf("failed to connect to AI model, status: %s, response: %s", resp.Status, string(body))
+		return Response{}, &ModelError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
 	}
 
-	return Response{}, fmt.Errorf("max retries reached, failed to connect to AI model")
+	return Response{}, ErrMaxRetries
+}
+
+// stringList flag yang boleh diisi berkali-kali, misalnya -compute a=... -compute b=...
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, "; ") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
 }
 
 func main() {
-	// Load environment variables from .env file
-	err := godotenv.Load()
+	var computed stringList
+	flag.Var(&computed, "compute", "add a computed column to the CSV table, e.g. \"Cost = Energy_Consumption * 1444.7\" (repeatable)")
+	grpcAddr := flag.String("grpc", "", "run the gRPC TableQA server on this address instead of the REPL")
+	httpAddr := flag.String("http", "", "run the HTTP API on this address instead of the REPL")
+	apiKeysFile := flag.String("api-keys", "", "JSON file with API keys for server mode (enables authentication)")
+	defaultOwner := flag.String("default-owner", "", "user that owns the CSV dataset when authentication is enabled")
+	defaultTenant := flag.String("default-tenant", "", "tenant of -default-owner (default: the owner's tenant in -api-keys, else the owner's name)")
+	maxDatasets := flag.Int("max-datasets", 0, "maximum datasets per tenant in server mode (0 = unlimited)")
+	maxRows := flag.Int("max-rows", 0, "maximum rows per dataset in server mode (0 = unlimited)")
+	flag.Int("queue-size", DefaultQueueSize, "model calls that may wait for a free slot before new questions are rejected as overloaded")
+	flag.Int("concurrency", DefaultConcurrency, "model calls running at the same time per backend")
+	flag.String("backend-concurrency", "", "per-backend limits overriding -concurrency, e.g. \"hf:google/tapas-large-finetuned-wtq=1\"")
+	historyFile := flag.String("history", "history.jsonl", "append-only history file (empty disables history)")
+	historyMaxAge := flag.Duration("history-max-age", 0, "drop history entries older than this (0 = keep forever)")
+	historyMaxEntries := flag.Int("history-max-entries", 0, "keep at most this many history entries (0 = unlimited)")
+	flag.String("translator", "lexicon", "how non-English questions are translated: lexicon, hf or none")
+	labelsFile := flag.String("labels", "labels.jsonl", "file where answers marked correct/wrong are stored as labeled examples")
+	flag.String("fallback-models", "", "comma-separated Huggingface models to try when the answer confidence is too low")
+	flag.Float64("min-confidence", 0.5, "minimum answer confidence before falling back to the next model")
+	normalize := flag.Bool("normalize", true, "lowercase questions, convert number words and correct spelling against the table before asking")
+	preAggregate := flag.Int("pre-aggregate", 0, "summarize tables with more rows than this (sum per date and mentioned column) before asking total, compare and trend questions (0 = never)")
+	maxTokens := flag.Int("max-tokens", 0, "estimate TAPAS tokens before asking and summarize or reject tables over this limit (512 for TAPAS, 0 = off)")
+	onnxModel := flag.String("onnx-model", "", "answer with this exported TAPAS ONNX model on the CPU instead of the Huggingface API (needs -tags onnx)")
+	flag.String("onnx-library", "", "path to the onnxruntime shared library (default: system library)")
+	flag.String("privacy", "", "anonymize columns before sending them to the model, e.g. \"Appliance=pseudonymize,Date=shift,Energy_Consumption=round:1\"")
+	flag.Int("privacy-date-shift", 0, "days to shift dates for -privacy shift columns (0 = random)")
+	sqa := flag.Bool("sqa", false, "use "+SQAModel+" and send the previous answer's cells with follow-up questions")
+	lang := flag.String("lang", "", "language for prompts and messages: en or id (default from APP_LOCALE or LANG)")
+	csvFile := flag.String("csv", "data-series.csv", "household CSV table to ask about")
+	flag.String("model-url", "", "Huggingface endpoint of the model (default "+DefaultModelURL+")")
+	flag.Int("max-retries", DefaultMaxRetries, "how many times to retry while the model is loading")
+	fakeModel := flag.Bool("fake-model", false, "answer with the built-in fake model server, no token needed (dev profile)")
+	configPath := flag.String("config", DefaultConfigFile, "YAML configuration file with profiles; flags override it")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "how long server mode waits for in-flight requests after SIGINT/SIGTERM before cancelling them")
+	profile := flag.String("profile", "", "configuration profile: dev, home, prod or one from the config file (default from TABLEQA_PROFILE)")
+	// Flag tanpa variabel (model, privasi, dispatcher, translator) dibaca lewat cfg setelah ApplyConfig, lihat BuildBackend dan BuildTranslator
+	flag.Parse()
+
+	// .env dibaca lebih dulu karena boleh berisi token, kunci enkripsi, dan override konfigurasi
+	envErr := godotenv.Load()
+
+	// Kunci enkripsi dibaca sebelum subcommand apa pun agar semua file yang dibaca dan ditulis
+	// aplikasi memakai kunci yang sama
+	storage, err := CipherFromEnv()
 	if err != nil {
-		log.Fatalf("Error loading .env file: %v\n", err)
+		log.Fatalln(err)
 	}
+	SetStorageCipher(storage)
 
-	// Get Huggingface API token dari environment variables
-	token := os.Getenv("HUGGINGFACE_TOKEN")
-	if token == "" {
-		log.Fatalf("HUGGINGFACE_TOKEN not found in .env file")
+	// Konfigurasi menjadi default semua flag yang tidak diisi di command line
+	configSet := false
+	flag.Visit(func(f *flag.Flag) { configSet = configSet || f.Name == "config" })
+	cfg, err := LoadConfig(*configPath, *profile, configSet, os.Getenv)
+	if err == nil {
+		cfg, err = ApplyConfig(flag.CommandLine, cfg)
+	}
+	if err != nil {
+		log.Fatalln(err)
 	}
 
-	// Path to CSV file
-	csvFile := "data-series.csv"
+	if err := SetLocale(ResolveLocale(*lang)); err != nil {
+		log.Fatalln(err)
+	}
 
-	// Baca CSV file
-	data, err := ioutil.ReadFile(csvFile)
-	if err != nil {
-		log.Fatalf("Error reading CSV file: %v\n", err)
+	// ctx dibatalkan oleh SIGINT/SIGTERM; setelah itu sinyal berikutnya kembali menghentikan
+	// proses langsung sehingga Ctrl-C kedua tetap bisa memaksa keluar
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// Subcommand "config show" menampilkan konfigurasi efektif dengan secret disamarkan
+	if flag.Arg(0) == "config" {
+		if err := runConfig(flag.Args()[1:], cfg); err != nil {
+			log.Fatalln(T("main.command_error", "config", err))
+		}
+		return
 	}
 
-	// Parse CSV to slice
-	table, err := CsvToSlice(string(data))
-	if err != nil {
-		log.Fatalf("Error parsing CSV file: %v\n", err)
+	// Subcommand "encrypt" mengenkripsi file lama atau membuat kunci baru
+	if flag.Arg(0) == "encrypt" {
+		if err := runEncrypt(flag.Args()[1:]); err != nil {
+			log.Fatalln(T("main.command_error", "encrypt", err))
+		}
+		return
 	}
 
-	// Buat AI model connector
-	client := &http.Client{}
-	connector := &AIModelConnector{Client: client}
+	// Subcommand "bench" tidak butuh token karena memakai fake server lokal
+	if flag.Arg(0) == "bench" {
+		if err := runBench(flag.Args()[1:]); err != nil {
+			log.Fatalln(T("main.command_error", "bench", err))
+		}
+		return
+	}
 
-	// Mulai interaksi chatbot
-	scanner := bufio.NewScanner(os.Stdin)
-	fmt.Println("AI-Powered Smart Home Energy Management System")
-	fmt.Println("Enter your query (type 'exit' to quit):")
+	// Subcommand "share" hanya membaca CSV dan file agregat, tidak memanggil model
+	if flag.Arg(0) == "share" {
+		if err := runShare(flag.Args()[1:], cfg); err != nil {
+			log.Fatalln(T("main.command_error", "share", err))
+		}
+		return
+	}
 
-	for {
-		fmt.Print("> ")
-		scanner.Scan()
-		query := scanner.Text()
+	// Token Huggingface dari konfigurasi atau HUGGINGFACE_TOKEN (biasanya di .env);
+	// fake server dan model ONNX lokal tidak membutuhkannya
+	token := cfg.Model.Token
+	if token == "" && !*fakeModel && *onnxModel == "" {
+		if envErr != nil {
+			log.Fatalln(T("main.env_error", envErr))
+		}
+		log.Fatalln(T("main.token_missing"))
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalln(err)
+	}
 
-		if strings.ToLower(query) == "exit" {
-			break
+	// Subcommand "batch" menjawab daftar pertanyaan dari file, opsional dengan -filter
+	if flag.Arg(0) == "batch" {
+		if err := runBatch(ctx, flag.Args()[1:], cfg); err != nil {
+			log.Fatalln(T("main.command_error", "batch", err))
 		}
+		return
+	}
 
-		payload := Inputs{
-			Table: table,
-			Query: query,
+	// Subcommand "eval" memutar ulang contoh berlabel ke sebuah model
+	if flag.Arg(0) == "eval" {
+		if err := runEval(ctx, flag.Args()[1:], cfg); err != nil {
+			log.Fatalln(T("main.command_error", "eval", err))
 		}
+		return
+	}
 
-		response, err := connector.ConnectAIModel(payload, token)
-		if err != nil {
-			log.Printf("Error connecting to AI model: %v\n", err)
-			continue
+	// Baca CSV file
+	data, err := ReadDataFile(*csvFile)
+	if err != nil {
+		log.Fatalln(T("main.csv_read_error", err))
+	}
+
+	// Parse CSV to slice
+	table, err := CsvToSlice(string(data))
+	if err != nil {
+		log.Fatalln(T("main.csv_parse_error", err))
+	}
+	for _, def := range computed {
+		if table, err = applyComputedColumn(table, def); err != nil {
+			log.Fatalln(T("repl.compute_error", err))
 		}
+	}
 
-		// Tampilkan respons
-		fmt.Println("Answer:", response.Answer)
-		fmt.Println("Coordinates:", response.Coordinates)
-		fmt.Println("Cells:", response.Cells)
-		fmt.Println("Aggregator:", response.Aggregator)
-		fmt.Println()
+	// Semua pemanggilan model dari REPL dan server melewati satu dispatcher; setiap model dibungkus
+	// sendiri agar batasnya per backend. Connector dipakai bersama karena aman untuk concurrency.
+	dispatcher, err := NewDispatcherFromConfig(cfg.Server)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	backend, closeBackend, err := BuildBackend(cfg, dispatcher)
+	if err != nil {
+		log.Fatalln(T("main.command_error", "backend", err))
+	}
+	defer closeBackend()
+	pipeline := &Pipeline{Backend: backend, Sequential: *sqa, Normalize: *normalize, PreAggregate: *preAggregate, MaxTokens: *maxTokens}
+	if *labelsFile != "" {
+		pipeline.Labels = &LabelStore{Path: *labelsFile}
+	}
+	if pipeline.Translator, err = BuildTranslator(cfg); err != nil {
+		log.Fatalln(err)
 	}
-}
 
+	// Buka history jika diaktifkan
+	var history *HistoryStore
+	if *historyFile != "" {
+		history, err = OpenHistory(*historyFile, Retention{MaxAge: *historyMaxAge, MaxEntries: *historyMaxEntries})
+		if err != nil {
+			log.Fatalln(T("main.history_error", err))
+		}
+		defer history.Close()
+		pipeline.History = history
+	}
 
+	// Mode server gRPC dan/atau HTTP
+	if *grpcAddr != "" || *httpAddr != "" {
+		server := NewTableQAServer(pipeline, NewDatasetRegistry(Quota{MaxDatasets: *maxDatasets, MaxRows: *maxRows}))
+		if err := setupServerAuth(server, *apiKeysFile, Principal{UserID: *defaultOwner, Tenant: *defaultTenant}, *csvFile, table); err != nil {
+			log.Fatalln(T("main.server_setup", err))
+		}
+		if err := RunServers(ctx, server, *grpcAddr, *httpAddr, *shutdownTimeout); err != nil {
+			// log.Fatalln tidak menjalankan defer, jadi history ditutup lebih dulu
+			if history != nil {
+				history.Close()
+			}
+			log.Fatalln(T("main.server_stopped", err))
+		}
+		return
+	}
+
+	RunREPL(ctx, pipeline, table, os.Stdin, os.Stdout)
+}
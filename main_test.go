@@ -55,6 +55,11 @@ value3,value4`
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(result).Should(Equal(expected))
 		})
+
+		It("rejects duplicate column names", func() {
+			_, err := main.CsvToSlice("Room,Room\nKitchen,Garage")
+			Expect(err).Should(HaveOccurred())
+		})
 	})
 
 	Describe("connectAIModel", func() {
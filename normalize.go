@@ -0,0 +1,283 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Correction struct satu kata yang diubah oleh normalisasi
+type Correction struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// baseVocabulary kata umum di pertanyaan tentang energi yang menjadi target koreksi. Urutan
+// menentukan prioritas jika dua kata sama dekatnya.
+var baseVocabulary = []string{
+	"what", "which", "when", "where", "how", "who", "was", "were", "is", "are", "the",
+	"average", "total", "sum", "mean", "maximum", "minimum", "highest", "lowest", "most", "least", "peak",
+	"energy", "power", "consumption", "consumed", "consume", "usage", "used", "use", "voltage", "kwh",
+	"compare", "comparison", "between", "versus", "difference", "trend", "change", "count", "number",
+	"much", "many", "times", "time", "day", "days", "daily", "week", "weekly", "month", "months", "monthly",
+	"year", "years", "yearly", "hour", "hours", "date", "per", "during", "over", "past", "last", "next",
+	"this", "that", "those", "these", "and", "for", "from", "with", "than", "more", "less", "same",
+	"recorded", "spent", "has", "have", "been", "did", "does", "all", "each", "every", "overall",
+	"predicted", "prediction", "forecast", "status", "off", "room", "rooms", "appliance", "appliances",
+	"show", "list", "give", "tell", "home", "house", "morning", "evening", "night",
+	"january", "february", "march", "april", "may", "june", "july", "august", "september",
+	"october", "november", "december",
+}
+
+// stopwords kata fungsi yang tidak pernah menjadi hasil koreksi: mengubah kata lain menjadi "is" atau
+// "may" mengubah arti pertanyaan, dan "may" bahkan bisa memicu filter bulan
+var stopwords = wordSet(
+	"what", "which", "when", "where", "how", "who", "whom", "why", "was", "were", "is", "are", "am", "be",
+	"the", "a", "an", "this", "that", "those", "these", "and", "or", "but", "for", "from", "with", "than",
+	"has", "have", "had", "been", "did", "does", "do", "all", "each", "every", "per", "over", "in", "on",
+	"at", "of", "to", "by", "it", "its", "may", "might", "can", "could", "will", "would", "most", "more",
+	"less", "least", "some", "any", "much", "many", "not", "no", "so", "as", "if", "into", "out", "up",
+)
+
+// abbreviations singkatan yang sering diketik dan bukan salah ketik
+var abbreviations = wordSet(
+	"avg", "max", "min", "sum", "tot", "amt", "qty", "pct", "kwh", "wh", "kw", "tv", "ac", "pc", "vs",
+	"approx", "temp", "hr", "hrs", "mins", "sec", "wk", "mo", "yr", "yrs",
+	"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+)
+
+// dictionaryWords kata bahasa Inggris umum, terutama benda rumah tangga dan istilah tagihan, yang
+// sudah benar walaupun tidak ada di tabel. Tanpa daftar ini "oven" dikoreksi menjadi "over" dan
+// "light" menjadi "night".
+var dictionaryWords = wordSet(
+	"oven", "fan", "fans", "light", "lights", "lamp", "lamps", "bulb", "heater", "heating", "cooling",
+	"washer", "washing", "machine", "dryer", "dishwasher", "microwave", "stove", "cooker", "kettle",
+	"toaster", "freezer", "fridge", "computer", "laptop", "router", "charger", "phone", "television",
+	"pump", "boiler", "iron", "vacuum", "garage", "bedroom", "bathroom", "office", "garden", "hall",
+	"cost", "costs", "price", "prices", "bill", "bills", "rate", "rates", "tariff", "money", "cheap",
+	"expensive", "save", "saving", "savings", "bigger", "biggest", "smaller", "smallest", "larger",
+	"largest", "higher", "lower", "top", "bottom", "first", "second", "third", "today", "yesterday",
+	"tomorrow", "weekend", "weekday", "afternoon", "noon", "midnight", "early", "late", "start", "end",
+	"running", "left", "turned", "still", "only", "also", "about", "around", "since",
+	"before", "after", "until", "between", "against", "without", "above", "below", "under", "again",
+	"value", "values", "amount", "level", "levels", "reading", "readings", "meter", "watt", "watts",
+	"kilowatt", "kilowatts", "hourly", "record", "records", "row", "rows", "column", "columns",
+	"table", "data", "item", "items", "device", "devices", "electric", "electricity", "gas", "water",
+	"air", "conditioner", "want", "know", "find", "see", "get", "got", "need", "like", "make", "made",
+	"take", "took", "keep", "kept", "run", "ran", "went", "go", "come", "came", "say", "said",
+	"there", "their", "they", "them", "then", "here", "our", "ours", "your", "yours", "my", "mine",
+	"we", "you", "me", "i", "he", "she", "him", "her", "his", "us", "what's", "whats", "please",
+	"often", "usually", "ever", "never", "always", "just", "very", "too",
+	"well", "good", "bad", "best", "worst", "low", "high", "big", "small", "long", "short", "new", "old",
+)
+
+// wordSet membuat set dari daftar kata
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// intentWords kata kunci intent satu kata; mengoreksinya berarti mengubah jenis pertanyaan
+var intentWords = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, ik := range intentKeywords {
+		for _, kw := range ik.keywords {
+			if w := strings.TrimSpace(kw); !strings.Contains(w, " ") {
+				set[w] = true
+			}
+		}
+	}
+	return set
+}()
+
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8,
+	"nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30,
+	"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+var (
+	numberPhrasePattern = regexp.MustCompile(`\b(?:zero|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty|thirty|forty|fifty|sixty|seventy|eighty|ninety|hundred|thousand)(?:(?:\s+|-)(?:and\s+)?(?:one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty|thirty|forty|fifty|sixty|seventy|eighty|ninety|hundred|thousand))*\b`)
+	// Karakter selain huruf, angka, spasi, dan tanda yang dipakai di angka, tanggal, atau jam dibuang
+	strayPunctuation = regexp.MustCompile(`[^\p{L}\p{N}\s\-.:,/?']`)
+	repeatedMarks    = regexp.MustCompile(`([?.,!])[?.,!]+`)
+	letterWord       = regexp.MustCompile(`\p{L}+`)
+)
+
+// Vocabulary struct kumpulan kata yang dikenal beserta bobotnya untuk koreksi ejaan
+type Vocabulary struct {
+	weights map[string]int
+}
+
+// NewVocabulary membangun kosakata dari kata umum, nama kolom, dan nilai teks di tabel.
+// Nama kolom seperti "Energy_Consumption" dipecah menjadi "energy" dan "consumption".
+func NewVocabulary(table map[string][]string) *Vocabulary {
+	v := &Vocabulary{weights: make(map[string]int)}
+	for i, w := range baseVocabulary {
+		v.weights[w] = 1000000 + len(baseVocabulary) - i
+	}
+	for col, values := range table {
+		v.addWords(col, 1000)
+		for _, value := range values {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				continue
+			}
+			v.addWords(value, 1)
+		}
+	}
+	return v
+}
+
+func (v *Vocabulary) addWords(text string, weight int) {
+	for _, w := range letterWord.FindAllString(strings.ToLower(text), -1) {
+		if len(w) >= 3 {
+			v.weights[w] += weight
+		}
+	}
+}
+
+// Contains mengecek apakah kata dikenal
+func (v *Vocabulary) Contains(word string) bool {
+	_, ok := v.weights[word]
+	return ok
+}
+
+// isKnownWord mengecek apakah kata sudah benar: ada di kosakata tabel, kata kunci intent,
+// singkatan, stopword, atau kamus kata umum
+func (v *Vocabulary) isKnownWord(word string) bool {
+	return v.Contains(word) || intentWords[word] || abbreviations[word] || stopwords[word] || dictionaryWords[word]
+}
+
+// minCorrectLength panjang minimal kata sebelum dikoreksi; kata tiga huruf terlalu mudah
+// berubah menjadi kata lain yang sah ("fan" menjadi "was")
+const minCorrectLength = 4
+
+// Correct mencari kata terdekat dengan jarak edit maksimal 1 (kata pendek) atau 2 (6 huruf ke atas).
+// Kata yang sudah dikenal tidak dikoreksi, dan hasil koreksi tidak pernah berupa stopword.
+func (v *Vocabulary) Correct(word string) (string, bool) {
+	if len(word) < minCorrectLength || v.isKnownWord(word) {
+		return word, false
+	}
+	maxDistance := 1
+	if len(word) >= 6 {
+		maxDistance = 2
+	}
+	best, bestDistance, bestWeight := "", maxDistance+1, -1
+	for candidate, weight := range v.weights {
+		if stopwords[candidate] || absInt(len(candidate)-len(word)) > maxDistance {
+			continue
+		}
+		d := editDistance(word, candidate)
+		if d < bestDistance || d == bestDistance && (weight > bestWeight || weight == bestWeight && candidate < best) {
+			best, bestDistance, bestWeight = candidate, d, weight
+		}
+	}
+	if best == "" {
+		return word, false
+	}
+	return best, true
+}
+
+// editDistance jarak Damerau-Levenshtein (transposisi dua huruf dihitung satu langkah)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// parseNumberWords mengubah frasa seperti "two thousand twenty three" menjadi 2023
+func parseNumberWords(phrase string) int {
+	total, current := 0, 0
+	for _, w := range strings.FieldsFunc(phrase, func(r rune) bool { return unicode.IsSpace(r) || r == '-' }) {
+		switch w {
+		case "and":
+		case "hundred":
+			if current == 0 {
+				current = 1
+			}
+			current *= 100
+		case "thousand":
+			if current == 0 {
+				current = 1
+			}
+			total += current * 1000
+			current = 0
+		default:
+			current += numberWords[w]
+		}
+	}
+	return total + current
+}
+
+// NormalizeQuery merapikan pertanyaan sebelum dikirim ke model: huruf kecil, tanda baca,
+// angka dalam kata menjadi digit, dan koreksi ejaan terhadap vocab. Corrections berisi
+// kata yang berubah selain huruf kecil dan tanda baca.
+func NormalizeQuery(query string, vocab *Vocabulary) (string, []Correction) {
+	text := strings.ToLower(query)
+	text = repeatedMarks.ReplaceAllString(text, "$1")
+	text = strayPunctuation.ReplaceAllString(text, " ")
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.Replace(text, " ?", "?", -1)
+
+	var corrections []Correction
+	text = numberPhrasePattern.ReplaceAllStringFunc(text, func(m string) string {
+		// "one" sendirian biasanya bukan angka ("which one"), jadi dibiarkan
+		if m == "one" {
+			return m
+		}
+		digits := strconv.Itoa(parseNumberWords(m))
+		corrections = append(corrections, Correction{From: m, To: digits})
+		return digits
+	})
+	if vocab != nil {
+		text = letterWord.ReplaceAllStringFunc(text, func(w string) string {
+			if _, isNumber := numberWords[w]; isNumber {
+				return w
+			}
+			fixed, changed := vocab.Correct(w)
+			if changed {
+				corrections = append(corrections, Correction{From: w, To: fixed})
+			}
+			return fixed
+		})
+	}
+	return text, corrections
+}
@@ -0,0 +1,87 @@
+package main_test
+
+import (
+	"context"
+	"io/ioutil"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query normalization", func() {
+	table := map[string][]string{
+		"Appliance":          {"Refrigerator", "Air Conditioner"},
+		"Date":               {"2023-06-01", "2023-06-02"},
+		"Energy_Consumption": {"1.2", "0.8"},
+		"Room":               {"Kitchen", "Living Room"},
+	}
+	vocab := main.NewVocabulary(table)
+
+	DescribeTable("normalizes and corrects",
+		func(query, expected string) {
+			got, _ := main.NormalizeQuery(query, vocab)
+			Expect(got).Should(Equal(expected))
+		},
+		Entry("misspellings", "what is the avrage enrgy in kitchn", "what is the average energy in kitchen"),
+		Entry("punctuation", "Total energy for the Refrigerator?!?", "total energy for the refrigerator?"),
+		Entry("number words", "energy in the last twenty-one days", "energy in the last 21 days"),
+		Entry("years in words", "consumption in two thousand twenty three", "consumption in 2023"),
+		Entry("dates and decimals untouched", "Was it above 1.5 on 2023-06-01 at 17:00?", "was it above 1.5 on 2023-06-01 at 17:00?"),
+		Entry("lone one is not a number", "which one used the most energy", "which one used the most energy"),
+	)
+
+	It("leaves correct words, keywords and abbreviations alone", func() {
+		data, err := ioutil.ReadFile("data-series.csv")
+		Expect(err).ShouldNot(HaveOccurred())
+		household, err := main.CsvToSlice(string(data))
+		Expect(err).ShouldNot(HaveOccurred())
+		vocab := main.NewVocabulary(household)
+		for _, word := range []string{"max", "min", "avg", "oven", "fan", "light", "cost", "sum", "kwh", "tv"} {
+			fixed, changed := vocab.Correct(word)
+			Expect(changed).Should(BeFalse(), word)
+			Expect(fixed).Should(Equal(word))
+		}
+		query := "what is the max energy of the oven, fan and light and the avg cost"
+		got, corrections := main.NormalizeQuery(query, vocab)
+		Expect(got).Should(Equal(query))
+		Expect(corrections).Should(BeEmpty())
+		_, ok := main.ResolveDateFilter(got)
+		Expect(ok).Should(BeFalse())
+		for query, intent := range map[string]string{"max energy of the tv": main.IntentMax, "min energy of the tv": main.IntentMin, "avg energy of the tv": main.IntentAverage} {
+			got, _ := main.NormalizeQuery(query, vocab)
+			Expect(main.ClassifyIntent(got)).Should(Equal(intent))
+		}
+
+		// Salah ketik tetap dikoreksi, tetapi tidak pernah menjadi stopword
+		fixed, changed := vocab.Correct("refrigerater")
+		Expect(changed).Should(BeTrue())
+		Expect(fixed).Should(Equal("refrigerator"))
+		for _, word := range []string{"mayy", "thhe", "wass"} {
+			fixed, _ := vocab.Correct(word)
+			Expect(fixed).ShouldNot(BeElementOf("may", "the", "was"))
+		}
+	})
+
+	It("reports only real corrections", func() {
+		_, corrections := main.NormalizeQuery("What is the Average energy?", vocab)
+		Expect(corrections).Should(BeEmpty())
+		_, corrections = main.NormalizeQuery("wat is the avrage energy in three days", vocab)
+		Expect(corrections).Should(ConsistOf(
+			main.Correction{From: "three", To: "3"},
+			main.Correction{From: "avrage", To: "average"},
+		))
+	})
+
+	It("sends the normalized query and keeps the corrections in the result", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "1.2", Coordinates: [][]int{{0, 2}}, Cells: []string{"1.2"}}}
+		pipeline := &main.Pipeline{Backend: backend, Normalize: true}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "what is the enrgy in kitchn on 2023-06-01"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[0].Query).Should(Equal("what is the energy in kitchen on 2023-06-01"))
+		Expect(backend.asked[0].Table["Date"]).Should(Equal([]string{"2023-06-01"}))
+		Expect(result.Corrections).Should(HaveLen(2))
+		Expect(result.Explanation.Corrections).Should(Equal(result.Corrections))
+	})
+})
@@ -0,0 +1,124 @@
+//go:build onnx
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+var (
+	onnxInitOnce sync.Once
+	onnxInitErr  error
+)
+
+// onnxSession struct TapasSession yang menjalankan model TAPAS hasil ekspor ONNX di CPU.
+// DynamicAdvancedSession aman dipakai bersamaan karena tensor dibuat per Run.
+type onnxSession struct {
+	session *ort.DynamicAdvancedSession
+	inputs  []string
+	// aggregators jumlah kepala agregasi, 0 jika model tidak punya output logits_aggregation
+	aggregators int
+}
+
+// NewONNXBackend memuat model TAPAS ONNX dari modelPath. library adalah path shared library
+// onnxruntime, kosong berarti memakai nama default sistem.
+func NewONNXBackend(modelPath, library string) (*LocalBackend, error) {
+	onnxInitOnce.Do(func() {
+		if library != "" {
+			ort.SetSharedLibraryPath(library)
+		}
+		onnxInitErr = ort.InitializeEnvironment()
+	})
+	if onnxInitErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocalModel, onnxInitErr)
+	}
+
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocalModel, err)
+	}
+	s := &onnxSession{}
+	outputNames := []string{"logits"}
+	for _, out := range outputs {
+		if out.Name == "logits_aggregation" {
+			s.aggregators = int(out.Dimensions[len(out.Dimensions)-1])
+			outputNames = append(outputNames, out.Name)
+		}
+	}
+	for _, in := range inputs {
+		s.inputs = append(s.inputs, in.Name)
+	}
+	s.session, err = ort.NewDynamicAdvancedSession(modelPath, s.inputs, outputNames, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLocalModel, err)
+	}
+	return &LocalBackend{Session: s, Model: modelPath}, nil
+}
+
+// Run membuat tensor input_ids, attention_mask, dan token_type_ids lalu menjalankan model
+func (s *onnxSession) Run(enc TapasEncoding) ([]float32, []float32, error) {
+	n := int64(len(enc.InputIDs))
+	byName := map[string][]int64{
+		"input_ids":      enc.InputIDs,
+		"attention_mask": enc.AttentionMask,
+		"token_type_ids": enc.TokenTypeIDs,
+	}
+	shapes := map[string]ort.Shape{
+		"input_ids":      ort.NewShape(1, n),
+		"attention_mask": ort.NewShape(1, n),
+		"token_type_ids": ort.NewShape(1, n, tapasTokenTypes),
+	}
+
+	var values []ort.ArbitraryTensor
+	defer func() {
+		for _, v := range values {
+			v.Destroy()
+		}
+	}()
+	var inputs []ort.ArbitraryTensor
+	for _, name := range s.inputs {
+		data, ok := byName[name]
+		if !ok {
+			return nil, nil, errors.New(T("model.unsupported_input", name))
+		}
+		t, err := ort.NewTensor(shapes[name], data)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, t)
+		inputs = append(inputs, t)
+	}
+
+	logits, err := ort.NewEmptyTensor[float32](ort.NewShape(1, n))
+	if err != nil {
+		return nil, nil, err
+	}
+	values = append(values, logits)
+	outputs := []ort.ArbitraryTensor{logits}
+	var aggregation *ort.Tensor[float32]
+	if s.aggregators > 0 {
+		if aggregation, err = ort.NewEmptyTensor[float32](ort.NewShape(1, int64(s.aggregators))); err != nil {
+			return nil, nil, err
+		}
+		values = append(values, aggregation)
+		outputs = append(outputs, aggregation)
+	}
+
+	if err := s.session.Run(inputs, outputs); err != nil {
+		return nil, nil, err
+	}
+	result := append([]float32(nil), logits.GetData()...)
+	if aggregation == nil {
+		return result, nil, nil
+	}
+	return result, append([]float32(nil), aggregation.GetData()...), nil
+}
+
+// Close melepaskan session ONNX Runtime
+func (s *onnxSession) Close() error {
+	return s.session.Destroy()
+}
@@ -0,0 +1,8 @@
+//go:build !onnx
+
+package main
+
+// NewONNXBackend tidak tersedia tanpa build tag onnx karena butuh cgo dan shared library onnxruntime
+func NewONNXBackend(modelPath, library string) (*LocalBackend, error) {
+	return nil, ErrONNXUnavailable
+}
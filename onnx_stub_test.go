@@ -0,0 +1,17 @@
+//go:build !onnx
+
+package main_test
+
+import (
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ONNX backend without the onnx build tag", func() {
+	It("reports that ONNX is not built in by default", func() {
+		_, err := main.NewONNXBackend("tapas-wtq.onnx", "")
+		Expect(err).Should(MatchError(main.ErrONNXUnavailable))
+	})
+})
@@ -0,0 +1,21 @@
+//go:build onnx
+
+package main_test
+
+import (
+	"path/filepath"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ONNX backend with the onnx build tag", func() {
+	It("reports a missing runtime library or model as a local model error", func() {
+		missing := filepath.Join(GinkgoT().TempDir(), "missing")
+		_, err := main.NewONNXBackend(filepath.Join(missing, "tapas-wtq.onnx"), filepath.Join(missing, "libonnxruntime.so"))
+		Expect(err).Should(MatchError(main.ErrLocalModel))
+		Expect(err).ShouldNot(MatchError(main.ErrONNXUnavailable))
+	})
+})
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrEmptyQuery dikembalikan jika pertanyaan kosong
+var ErrEmptyQuery = messageError("error.empty_query")
+
+// Pipeline struct untuk menjalankan alur tanya-jawab tabel ke sebuah Backend
+type Pipeline struct {
+	Backend Backend
+	// History opsional, jika diisi setiap pertanyaan akan dicatat
+	History *HistoryStore
+	// Labels opsional, tujuan contoh berlabel dari feedback user
+	Labels *LabelStore
+	// Translator opsional, menerjemahkan pertanyaan non-Inggris sebelum dikirim ke TAPAS
+	Translator QueryTranslator
+	// Normalize merapikan pertanyaan dan mengoreksi ejaan terhadap kosakata tabel sebelum dikirim
+	Normalize bool
+	// Sequential mengirim Question.PrevQueries dan PrevCoordinates ke backend (mode SQA)
+	Sequential bool
+	// PreAggregate jumlah baris maksimal sebelum tabel diringkas dengan PreAggregate, 0 berarti tidak pernah
+	PreAggregate int
+	// MaxTokens batas token TAPAS; jika diisi, tabel yang diperkirakan terpotong diringkas dulu
+	// atau ditolak dengan ErrTableTooLarge. 0 berarti tidak diperiksa.
+	MaxTokens int
+}
+
+// Question struct satu pertanyaan terhadap sebuah tabel
+type Question struct {
+	DatasetID string
+	Table     map[string][]string
+	Query     string
+	// PrevQueries pertanyaan sebelumnya dalam percakapan seperti yang dikirim ke model (Result.Query),
+	// hanya dipakai jika Pipeline.Sequential aktif
+	PrevQueries []string
+	// PrevCoordinates cell jawaban sebelumnya dengan indeks baris di tabel asli untuk TAPAS lokal,
+	// hanya dipakai jika Pipeline.Sequential aktif
+	PrevCoordinates [][]int
+	// Filter opsional seperti `Room = "Kitchen" and Energy_Consumption > 1`, diterapkan
+	// sebelum filter tanggal dari pertanyaan
+	Filter string
+}
+
+// Result struct untuk menyimpan hasil satu pertanyaan beserta metadata-nya
+type Result struct {
+	ID string
+	// Original pertanyaan seperti yang diketik user, Query versi bahasa Inggris yang dikirim
+	Original string
+	Query    string
+	Language string
+	// Corrections kata yang diubah oleh normalisasi, ditampilkan ke user jika tidak kosong
+	Corrections []Correction
+	// Answer jawaban untuk user dalam bahasanya sendiri
+	Answer string
+	// Unit satuan jawaban, misalnya "kWh" jika semua cell berasal dari kolom energi
+	Unit    string
+	Filters []string
+	// PreAggregation ringkasan yang dikirim menggantikan baris asli, misalnya "sum(Energy_Consumption) by Date"
+	PreAggregation string
+	RowsSent       int
+	// Tokens perkiraan token request, hanya diisi jika Pipeline.MaxTokens aktif
+	Tokens   *TokenEstimate
+	Response Response
+	Backend  string
+	Latency  time.Duration
+	// Route backend yang dicoba oleh RouterBackend, kosong untuk backend tunggal
+	Route []RouteAttempt
+	// Explanation jejak bagaimana jawaban diperoleh, untuk :explain dan API
+	Explanation Explanation
+}
+
+// Ask fungsi untuk memfilter tabel sesuai pertanyaan lalu mengirimnya ke backend
+func (p *Pipeline) Ask(ctx context.Context, q Question) (Result, error) {
+	query := strings.TrimSpace(q.Query)
+	if query == "" {
+		return Result{}, ErrEmptyQuery
+	}
+	result, err := p.ask(ctx, q, query)
+	// Pertanyaan yang gagal sebelum sampai ke backend (terjemahan, filter, batas token) tetap dicatat
+	if p.History != nil {
+		p.record(ctx, q, &result, err)
+	}
+	return result, err
+}
+
+// ask menjalankan Ask untuk pertanyaan yang sudah divalidasi tanpa mencatat history
+func (p *Pipeline) ask(ctx context.Context, q Question, query string) (Result, error) {
+	table := q.Table
+	result := Result{Original: query, Query: query, Language: DetectLanguage(query), Backend: p.Backend.Name()}
+	if p.Translator != nil && result.Language != LangEnglish {
+		translated, err := p.Translator.ToEnglish(ctx, query)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", T("pipeline.translate"), err)
+		}
+		result.Query = translated
+	}
+	if p.Normalize {
+		result.Query, result.Corrections = NormalizeQuery(result.Query, NewVocabulary(table))
+	}
+	query = result.Query
+
+	// rows menyimpan indeks baris asli yang dikirim, nil berarti semua baris
+	var rows []int
+	if strings.TrimSpace(q.Filter) != "" {
+		filtered, keep, err := ApplyFilter(table, q.Filter)
+		if err != nil {
+			return result, err
+		}
+		if len(keep) == 0 {
+			return result, ErrFilterNoRows
+		}
+		table, rows = filtered, keep
+		result.Filters = append(result.Filters, strings.TrimSpace(q.Filter))
+	}
+
+	// Filter tanggal dari pertanyaan, diabaikan jika tidak ada baris yang cocok
+	if col, ok := dateColumn(table); ok {
+		if filter, ok := ResolveDateFilter(query); ok {
+			filter.Column = col
+			if keep := dateFilterRows(table, filter); len(keep) > 0 {
+				table = selectRows(table, keep)
+				rows = composeRows(rows, keep)
+				result.Filters = append(result.Filters, filter.String())
+			} else {
+				result.Filters = append(result.Filters, T("pipeline.filter_skipped", filter))
+			}
+		}
+	}
+	// Tabel yang terlalu besar diringkas; baris ringkasan tidak lagi punya satu baris asli
+	if summary, desc, ok := PreAggregate(table, query, p.PreAggregate); ok {
+		table, rows = summary, nil
+		result.PreAggregation = desc
+	}
+	if p.MaxTokens > 0 {
+		tokens := EstimateTokens(Inputs{Table: table, Query: query}, p.MaxTokens)
+		// Tabel yang akan terpotong diringkas walaupun belum melewati batas PreAggregate; PreAggregate
+		// menolak sendiri jika ringkasan menghilangkan kelompok atau kolom yang ditanyakan
+		if tokens.Truncated && result.PreAggregation == "" {
+			if summary, desc, ok := PreAggregate(table, query, 1); ok {
+				table, rows = summary, nil
+				result.PreAggregation = desc
+				tokens = EstimateTokens(Inputs{Table: table, Query: query}, p.MaxTokens)
+			}
+		}
+		result.Tokens = &tokens
+		if tokens.Truncated {
+			result.RowsSent = tableRows(table)
+			return result, fmt.Errorf("%w: %s", ErrTableTooLarge, T("error.table_too_large_detail", tokens.Total, tokens.Limit, tokens.RowsFit, tokens.Rows))
+		}
+	}
+	result.RowsSent = tableRows(table)
+
+	// Catat backend yang dicoba jika Backend berupa RouterBackend
+	ctx = WithRoute(ctx, func(attempt RouteAttempt) {
+		result.Route = append(result.Route, attempt)
+	})
+	start := time.Now()
+	inputs := Inputs{Table: table, Query: query}
+	if p.Sequential {
+		inputs.PrevQueries = q.PrevQueries
+		// Ringkasan PreAggregate punya baris dan kolom sendiri, jadi cell jawaban sebelumnya tidak bisa
+		// ditunjuk di tabel itu dan tidak dikirim
+		if result.PreAggregation == "" {
+			inputs.PrevCoordinates = remapCoordinates(q.PrevCoordinates, rows)
+		}
+	}
+	response, err := p.Backend.Ask(ctx, inputs)
+	result.Response = response
+	result.Latency = time.Since(start)
+	if err == nil {
+		result.Answer = FormatAnswer(response, result.Language)
+		result.Unit = AnswerUnit(table, response, result.Language)
+		result.Explanation = explain(result, q.Table, table, rows)
+	}
+	return result, err
+}
+
+func (p *Pipeline) record(ctx context.Context, q Question, result *Result, askErr error) {
+	entry := HistoryEntry{
+		DatasetID: q.DatasetID,
+		Question:  result.Original,
+		Filter:    strings.TrimSpace(q.Filter),
+		Filters:   result.Filters,
+		Backend:   result.Backend,
+		Response:  result.Response,
+		LatencyMs: result.Latency.Milliseconds(),
+	}
+	if result.Query != result.Original {
+		entry.Translated = result.Query
+	}
+	principal := principalOrLocal(ctx)
+	entry.User, entry.Tenant = principal.UserID, principal.Tenant
+	if askErr != nil {
+		entry.Error = askErr.Error()
+	}
+	id, err := p.History.Record(entry)
+	if err != nil {
+		// History tidak boleh menggagalkan jawaban
+		log.Println(T("history.write_error", err))
+		return
+	}
+	result.ID = id
+}
+
+// SourceCoordinates mengembalikan koordinat jawaban dengan indeks baris di tabel asli,
+// dipakai sebagai Question.PrevCoordinates untuk pertanyaan lanjutan. Jawaban dari ringkasan
+// PreAggregate tidak menunjuk cell di tabel asli sehingga hasilnya nil.
+func (r Result) SourceCoordinates() [][]int {
+	if r.PreAggregation != "" {
+		return nil
+	}
+	coords := make([][]int, 0, len(r.Response.Coordinates))
+	// Explanation.Cells hanya berisi koordinat yang valid, jadi indeksnya dihitung terpisah
+	cell := 0
+	for _, c := range r.Response.Coordinates {
+		if !validCoordinate(c) {
+			continue
+		}
+		row := c[0]
+		if cell < len(r.Explanation.Cells) {
+			row = r.Explanation.Cells[cell].SourceRow
+		}
+		cell++
+		coords = append(coords, []int{row, c[1]})
+	}
+	return coords
+}
+
+// remapCoordinates mengubah baris asli menjadi baris di tabel yang dikirim.
+// Cell yang barisnya tidak ikut dikirim atau formatnya salah dibuang; rows nil berarti tabel tidak difilter.
+func remapCoordinates(coords [][]int, rows []int) [][]int {
+	if len(coords) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(rows))
+	for i, row := range rows {
+		index[row] = i
+	}
+	remapped := make([][]int, 0, len(coords))
+	for _, c := range coords {
+		if len(c) != 2 || c[0] < 0 || c[1] < 0 {
+			continue
+		}
+		if rows == nil {
+			remapped = append(remapped, []int{c[0], c[1]})
+		} else if i, ok := index[c[0]]; ok {
+			remapped = append(remapped, []int{i, c[1]})
+		}
+	}
+	return remapped
+}
+
+// remapColumns menerjemahkan indeks kolom koordinat dari urutan kolom from ke urutan kolom to,
+// misalnya setelah :compute menambah kolom; cell yang kolomnya tidak ada lagi dibuang
+func remapColumns(coords [][]int, from, to []string) [][]int {
+	index := make(map[string]int, len(to))
+	for i, col := range to {
+		index[col] = i
+	}
+	var remapped [][]int
+	for _, c := range coords {
+		if !validCoordinate(c) || c[1] >= len(from) {
+			continue
+		}
+		if i, ok := index[from[c[1]]]; ok {
+			remapped = append(remapped, []int{c[0], i})
+		}
+	}
+	return remapped
+}
+
+func tableRows(table map[string][]string) int {
+	for _, values := range table {
+		return len(values)
+	}
+	return 0
+}
+
+// sortedColumns mengembalikan nama kolom urut abjad. Urutan ini sama dengan indeks kolom
+// di Response.Coordinates karena json.Marshal mengurutkan key map.
+func sortedColumns(table map[string][]string) []string {
+	columns := make([]string, 0, len(table))
+	for col := range table {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+	return columns
+}
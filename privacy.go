@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPrivacy dikembalikan jika aturan privasi kolom tidak bisa dibaca
+var ErrInvalidPrivacy = messageError("error.invalid_privacy")
+
+// ColumnPrivacy struct aturan privasi satu kolom sebelum tabel dikirim ke model remote
+type ColumnPrivacy struct {
+	// Pseudonymize mengganti setiap nilai berbeda dengan nama samaran seperti "Appliance_1"
+	Pseudonymize bool
+	// ShiftDates menggeser tanggal sebanyak PrivateBackend.DateShift hari
+	ShiftDates bool
+	// Round membulatkan angka menjadi Decimals angka desimal
+	Round    bool
+	Decimals int
+}
+
+// ParsePrivacy membaca aturan privasi per kolom, misalnya
+// "Appliance=pseudonymize,Room=pseudonymize,Date=shift,Energy_Consumption=round:1"
+func ParsePrivacy(spec string) (map[string]ColumnPrivacy, error) {
+	columns := make(map[string]ColumnPrivacy)
+	for _, part := range strings.Split(spec, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		col, rule, ok := strings.Cut(part, "=")
+		col, rule = strings.TrimSpace(col), strings.TrimSpace(rule)
+		if !ok || col == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPrivacy, T("privacy.syntax", part))
+		}
+		p := columns[col]
+		switch name, arg, _ := strings.Cut(rule, ":"); name {
+		case "pseudonymize":
+			p.Pseudonymize = true
+		case "shift":
+			p.ShiftDates = true
+		case "round":
+			p.Round = true
+			if arg != "" {
+				n, err := strconv.Atoi(arg)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidPrivacy, T("privacy.decimals", arg, col))
+				}
+				p.Decimals = n
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPrivacy, T("privacy.unknown_rule", rule, col))
+		}
+		columns[col] = p
+	}
+	return columns, nil
+}
+
+// PrivateBackend struct membungkus Backend lain agar data rumah tangga tidak terkirim apa adanya.
+// Nama disamarkan, tanggal digeser, dan angka dibulatkan sesuai Columns; Cells dan Answer dari
+// model dikembalikan ke nilai asli lewat Coordinates sehingga pemanggil tidak melihat perbedaannya.
+type PrivateBackend struct {
+	Backend Backend
+	Columns map[string]ColumnPrivacy
+	// DateShift jumlah hari pergeseran tanggal, sebaiknya acak dan dirahasiakan
+	DateShift int
+}
+
+// Name mengembalikan nama backend yang dibungkus
+func (b *PrivateBackend) Name() string {
+	return "private:" + b.Backend.Name()
+}
+
+// Ask menyamarkan payload, meneruskannya ke Backend, lalu mengembalikan nilai aslinya
+func (b *PrivateBackend) Ask(ctx context.Context, payload Inputs) (Response, error) {
+	masked, reverse := b.mask(payload)
+	resp, err := b.Backend.Ask(ctx, masked)
+	if err != nil {
+		return resp, err
+	}
+	return unmaskResponse(resp, payload.Table, reverse), nil
+}
+
+// shiftLayouts format tanggal yang digeser; format jam saja tidak mengandung tanggal
+var shiftLayouts = []string{dateLayout, "2006-01-02 15:04:05", time.RFC3339}
+
+// mask membuat salinan payload yang sudah disamarkan beserta peta nilai samaran ke nilai asli
+func (b *PrivateBackend) mask(payload Inputs) (Inputs, map[string]string) {
+	reverse := make(map[string]string)
+	masked := payload
+	masked.Table = make(map[string][]string, len(payload.Table))
+	queryNames := make(map[string]string)
+	shiftQuery := false
+
+	for _, col := range sortedColumns(payload.Table) {
+		cells := payload.Table[col]
+		rule, ok := b.Columns[col]
+		if !ok {
+			masked.Table[col] = cells
+			continue
+		}
+		out := make([]string, len(cells))
+		pseudonyms := make(map[string]string)
+		for i, cell := range cells {
+			out[i] = cell
+			switch {
+			case strings.TrimSpace(cell) == "":
+			case rule.Pseudonymize:
+				name, ok := pseudonyms[cell]
+				if !ok {
+					name = fmt.Sprintf("%s_%d", col, len(pseudonyms)+1)
+					pseudonyms[cell] = name
+					reverse[name] = cell
+					queryNames[cell] = name
+				}
+				out[i] = name
+			case rule.ShiftDates:
+				out[i] = shiftDate(cell, b.DateShift)
+				reverse[out[i]] = cell
+				shiftQuery = true
+			case rule.Round:
+				out[i] = roundCell(cell, rule.Decimals)
+			}
+		}
+		masked.Table[col] = out
+	}
+
+	masked.Query = replaceNames(payload.Query, queryNames)
+	if shiftQuery {
+		masked.Query = isoDatePattern.ReplaceAllStringFunc(masked.Query, func(date string) string {
+			return shiftDate(date, b.DateShift)
+		})
+	}
+	return masked, reverse
+}
+
+// shiftDate menggeser tanggal sebanyak days hari dengan format yang sama; nilai lain tidak diubah
+func shiftDate(cell string, days int) string {
+	for _, layout := range shiftLayouts {
+		if t, err := time.Parse(layout, cell); err == nil {
+			return t.AddDate(0, 0, days).Format(layout)
+		}
+	}
+	return cell
+}
+
+// roundCell membulatkan angka ke decimals angka desimal; nilai lain tidak diubah
+func roundCell(cell string, decimals int) string {
+	v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+	if err != nil {
+		return cell
+	}
+	scale := math.Pow(10, float64(decimals))
+	return strconv.FormatFloat(math.Round(v*scale)/scale, 'f', decimals, 64)
+}
+
+// replaceNames mengganti nama asli yang disebut di pertanyaan dengan nama samarannya,
+// nama terpanjang lebih dulu agar "Living Room" tidak terganti sebagian oleh "Room"
+func replaceNames(query string, names map[string]string) string {
+	originals := make([]string, 0, len(names))
+	for name := range names {
+		originals = append(originals, name)
+	}
+	sort.Slice(originals, func(i, j int) bool { return len(originals[i]) > len(originals[j]) })
+	for _, name := range originals {
+		pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`)
+		query = pattern.ReplaceAllLiteralString(query, names[name])
+	}
+	return query
+}
+
+// unmaskResponse mengembalikan Cells ke nilai asli. Jika Coordinates sejajar dengan Cells,
+// nilai diambil langsung dari tabel asli (termasuk angka yang dibulatkan) dan Answer disusun
+// ulang; jika tidak, nama samaran dan tanggal geseran diganti lewat reverse.
+func unmaskResponse(resp Response, table map[string][]string, reverse map[string]string) Response {
+	columns := sortedColumns(table)
+	cells := make([]string, len(resp.Cells))
+	aligned := len(resp.Coordinates) == len(resp.Cells)
+	for i, cell := range resp.Cells {
+		cells[i] = cell
+		if original, ok := reverse[cell]; ok {
+			cells[i] = original
+		}
+		if !aligned {
+			continue
+		}
+		if c := resp.Coordinates[i]; len(c) == 2 && c[1] >= 0 && c[1] < len(columns) && c[0] >= 0 && c[0] < len(table[columns[c[1]]]) {
+			cells[i] = table[columns[c[1]]][c[0]]
+		} else {
+			aligned = false
+		}
+	}
+
+	if aligned && len(cells) > 0 {
+		resp.Answer = tapasAnswer(strings.ToUpper(resp.Aggregator), cells)
+	} else {
+		// Samaran terpanjang lebih dulu agar "Room_10" tidak terbaca sebagai "Room_1" + "0"
+		masked := make([]string, 0, len(reverse))
+		for m := range reverse {
+			masked = append(masked, m)
+		}
+		sort.Slice(masked, func(i, j int) bool { return len(masked[i]) > len(masked[j]) })
+		pairs := make([]string, 0, len(reverse)*2)
+		for _, m := range masked {
+			pairs = append(pairs, m, reverse[m])
+		}
+		resp.Answer = strings.NewReplacer(pairs...).Replace(resp.Answer)
+	}
+	resp.Cells = cells
+	return resp
+}
@@ -0,0 +1,78 @@
+package main_test
+
+import (
+	"context"
+	"errors"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Privacy mode", func() {
+	table := map[string][]string{
+		"Appliance":          {"Refrigerator", "TV", "Refrigerator", "Heater"},
+		"Date":               {"2022-06-01", "2022-06-01", "2022-06-02", ""},
+		"Energy_Consumption": {"1.23", "0.47", "1.18", "2.05"},
+		"Room":               {"Kitchen", "Living Room", "Kitchen", "Bedroom"},
+	}
+	columns, err := main.ParsePrivacy("Appliance=pseudonymize, Room=pseudonymize,Date=shift,Energy_Consumption=round:1")
+
+	It("parses per-column rules", func() {
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(columns["Energy_Consumption"]).Should(Equal(main.ColumnPrivacy{Round: true, Decimals: 1}))
+		Expect(columns["Date"].ShiftDates).Should(BeTrue())
+
+		_, err := main.ParsePrivacy("Room=hide")
+		Expect(err).Should(MatchError(main.ErrInvalidPrivacy))
+		_, err = main.ParsePrivacy("Energy_Consumption=round:x")
+		Expect(err).Should(MatchError(ContainSubstring("invalid decimals")))
+		_, err = main.ParsePrivacy("Room")
+		Expect(err).Should(MatchError(ContainSubstring("must look like")))
+	})
+
+	It("sends pseudonyms, shifted dates and rounded values", func() {
+		backend := &fakeBackend{name: "hf", response: main.Response{Aggregator: "NONE"}}
+		private := &main.PrivateBackend{Backend: backend, Columns: columns, DateShift: 40}
+		_, err := private.Ask(context.Background(), main.Inputs{Table: table, Query: "How much energy did the refrigerator in the living room use on 2022-06-02?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(private.Name()).Should(Equal("private:hf"))
+
+		sent := backend.asked[0]
+		Expect(sent.Table).Should(Equal(map[string][]string{
+			"Appliance":          {"Appliance_1", "Appliance_2", "Appliance_1", "Appliance_3"},
+			"Date":               {"2022-07-11", "2022-07-11", "2022-07-12", ""},
+			"Energy_Consumption": {"1.2", "0.5", "1.2", "2.1"},
+			"Room":               {"Room_1", "Room_2", "Room_1", "Room_3"},
+		}))
+		Expect(sent.Query).Should(Equal("How much energy did the Appliance_1 in the Room_2 use on 2022-07-12?"))
+		Expect(table["Appliance"][0]).Should(Equal("Refrigerator"))
+	})
+
+	It("maps the answer back to the real values", func() {
+		backend := &fakeBackend{response: main.Response{
+			Answer:      "SUM > 1.2, 1.2",
+			Coordinates: [][]int{{0, 2}, {2, 2}},
+			Cells:       []string{"1.2", "1.2"},
+			Aggregator:  "SUM",
+		}}
+		private := &main.PrivateBackend{Backend: backend, Columns: columns, DateShift: 40}
+		result, err := (&main.Pipeline{Backend: private}).Ask(context.Background(), main.Question{Table: table, Query: "How much energy did the refrigerator use?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Answer).Should(Equal("SUM > 1.23, 1.18"))
+		value, ok := main.ComputeAnswer(result.Response)
+		Expect(ok).Should(BeTrue())
+		Expect(value).Should(Equal("2.41"))
+
+		backend.response = main.Response{Answer: "Appliance_3", Cells: []string{"Appliance_3"}, Aggregator: "NONE"}
+		resp, err := private.Ask(context.Background(), main.Inputs{Table: table, Query: "Which appliance used the most energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Answer).Should(Equal("Heater"))
+		Expect(resp.Cells).Should(Equal([]string{"Heater"}))
+
+		backend.err = errors.New("offline")
+		_, err = private.Ask(context.Background(), main.Inputs{Table: table, Query: "Which?"})
+		Expect(err).Should(MatchError("offline"))
+	})
+})
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Role hak akses user terhadap sebuah dataset
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleViewer Role = "viewer"
+)
+
+var (
+	// ErrDatasetNotFound juga dipakai jika dataset ada tetapi bukan milik user, agar tidak bocor
+	ErrDatasetNotFound  = messageError("error.dataset_not_found")
+	ErrPermissionDenied = messageError("error.permission_denied")
+	ErrQuotaExceeded    = messageError("error.quota_exceeded")
+	ErrInvalidRole      = messageError("error.invalid_role")
+)
+
+// Quota struct batas penggunaan per tenant, nilai 0 berarti tidak dibatasi
+type Quota struct {
+	MaxDatasets int `json:"max_datasets"`
+	MaxRows     int `json:"max_rows"`
+}
+
+// Dataset struct satu tabel rumah tangga beserta pemilik dan hak aksesnya
+type Dataset struct {
+	ID     string
+	Name   string
+	Tenant string
+	Owner  string
+	Table  map[string][]string
+	// Grants role per user, dikunci dengan tenant dan user karena UserID hanya unik di dalam satu tenant
+	Grants map[Principal]Role
+}
+
+// Info mengembalikan ringkasan dataset
+func (d *Dataset) Info() DatasetInfo {
+	return DatasetInfo{ID: d.ID, Name: d.Name, Columns: sortedColumns(d.Table), Rows: tableRows(d.Table)}
+}
+
+func (d *Dataset) roleOf(p Principal) (Role, bool) {
+	if p.UserID == d.Owner && p.Tenant == d.Tenant {
+		return RoleOwner, true
+	}
+	role, ok := d.Grants[p]
+	return role, ok
+}
+
+// datasetKey id dataset hanya unik di dalam satu tenant, jadi tenant lain bisa memakai id yang sama
+type datasetKey struct {
+	Tenant string
+	ID     string
+}
+
+// DatasetRegistry struct untuk menyimpan banyak dataset dengan isolasi antar tenant
+type DatasetRegistry struct {
+	DefaultQuota Quota
+
+	mu       sync.RWMutex
+	datasets map[datasetKey]*Dataset
+	quotas   map[string]Quota
+}
+
+// NewDatasetRegistry membuat registry kosong dengan quota default
+func NewDatasetRegistry(defaultQuota Quota) *DatasetRegistry {
+	return &DatasetRegistry{
+		DefaultQuota: defaultQuota,
+		datasets:     make(map[datasetKey]*Dataset),
+		quotas:       make(map[string]Quota),
+	}
+}
+
+// SetQuota mengatur quota khusus untuk satu tenant
+func (r *DatasetRegistry) SetQuota(tenant string, quota Quota) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quotas[tenant] = quota
+}
+
+func (r *DatasetRegistry) quotaFor(tenant string) Quota {
+	if q, ok := r.quotas[tenant]; ok {
+		return q
+	}
+	return r.DefaultQuota
+}
+
+// lookup mencari dataset id yang bisa diakses principal: milik tenant-nya sendiri lebih dulu, lalu
+// dataset tenant lain yang dibagikan kepadanya (urut tenant agar hasilnya tetap). Dataset tenant lain
+// yang tidak dibagikan tidak terlihat sama sekali.
+func (r *DatasetRegistry) lookup(p Principal, id string) (*Dataset, Role, bool) {
+	if ds, ok := r.datasets[datasetKey{Tenant: p.Tenant, ID: id}]; ok {
+		if role, ok := ds.roleOf(p); ok {
+			return ds, role, true
+		}
+	}
+	var found *Dataset
+	var foundRole Role
+	for key, ds := range r.datasets {
+		if key.ID != id || key.Tenant == p.Tenant || (found != nil && found.Tenant < ds.Tenant) {
+			continue
+		}
+		if role, ok := ds.roleOf(p); ok {
+			found, foundRole = ds, role
+		}
+	}
+	return found, foundRole, found != nil
+}
+
+// Put menyimpan dataset baru di tenant principal atau mengganti dataset yang ia miliki
+func (r *DatasetRegistry) Put(p Principal, id, name string, table map[string][]string) (DatasetInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ds := &Dataset{ID: id, Name: name, Tenant: p.Tenant, Owner: p.UserID, Table: table, Grants: map[Principal]Role{}}
+	existing, role, exists := r.lookup(p, id)
+	if exists {
+		if role != RoleOwner {
+			return DatasetInfo{}, ErrPermissionDenied
+		}
+		// Dataset tetap milik tenant asal walaupun diganti oleh owner lain
+		ds.Tenant, ds.Owner, ds.Grants = existing.Tenant, existing.Owner, existing.Grants
+	}
+
+	quota := r.quotaFor(ds.Tenant)
+	if quota.MaxRows > 0 {
+		for _, values := range table {
+			if len(values) > quota.MaxRows {
+				return DatasetInfo{}, fmt.Errorf("%w: %s", ErrQuotaExceeded, T("error.quota_rows", len(values), quota.MaxRows))
+			}
+			break
+		}
+	}
+	if !exists && quota.MaxDatasets > 0 {
+		count := 0
+		for _, other := range r.datasets {
+			if other.Tenant == ds.Tenant {
+				count++
+			}
+		}
+		if count >= quota.MaxDatasets {
+			return DatasetInfo{}, fmt.Errorf("%w: %s", ErrQuotaExceeded, T("error.quota_datasets", ds.Tenant, count))
+		}
+	}
+
+	r.datasets[datasetKey{Tenant: ds.Tenant, ID: id}] = ds
+	return ds.Info(), nil
+}
+
+// Table mengambil tabel dataset jika principal punya akses owner atau viewer
+func (r *DatasetRegistry) Table(p Principal, id string) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ds, _, ok := r.lookup(p, id)
+	if !ok {
+		return nil, ErrDatasetNotFound
+	}
+	return ds.Table, nil
+}
+
+// List mengembalikan dataset yang bisa diakses principal, urut berdasarkan id. Jika dua dataset punya
+// id yang sama hanya yang dipakai oleh Table yang ditampilkan.
+func (r *DatasetRegistry) List(p Principal) []DatasetInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]DatasetInfo, 0)
+	for key, ds := range r.datasets {
+		if found, _, ok := r.lookup(p, key.ID); ok && found == ds {
+			infos = append(infos, ds.Info())
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// Grant memberi role ke user lain, hanya owner yang boleh. Tenant grantee yang kosong berarti tenant dataset.
+func (r *DatasetRegistry) Grant(p Principal, id string, grantee Principal, role Role) error {
+	if role != RoleViewer && role != RoleOwner {
+		return ErrInvalidRole
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ds, current, ok := r.lookup(p, id)
+	if !ok {
+		return ErrDatasetNotFound
+	}
+	if current != RoleOwner {
+		return ErrPermissionDenied
+	}
+	if grantee.Tenant == "" {
+		grantee.Tenant = ds.Tenant
+	}
+	ds.Grants[grantee] = role
+	return nil
+}
@@ -0,0 +1,135 @@
+package main_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func signJWT(secret, claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("DatasetRegistry", func() {
+	alice := main.Principal{UserID: "alice", Tenant: "house-a"}
+	bob := main.Principal{UserID: "bob", Tenant: "house-b"}
+	table := map[string][]string{"Room": {"Kitchen", "Garage"}}
+
+	It("isolates datasets between tenants", func() {
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(alice, "a", "Alice", table)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = registry.Table(bob, "a")
+		Expect(err).Should(MatchError(main.ErrDatasetNotFound))
+		Expect(registry.List(bob)).Should(BeEmpty())
+
+		// Id dataset hanya unik di dalam tenant: bob membuat "a" sendiri tanpa mengetahui milik alice
+		bobTable := map[string][]string{"Room": {"Bedroom"}}
+		_, err = registry.Put(bob, "a", "Bob", bobTable)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registry.Table(bob, "a")).Should(Equal(bobTable))
+		Expect(registry.Table(alice, "a")).Should(Equal(table))
+		Expect(registry.Grant(bob, "a", main.Principal{UserID: "carol"}, main.RoleViewer)).Should(Succeed())
+		Expect(registry.Table(main.Principal{UserID: "carol", Tenant: "house-b"}, "a")).Should(Equal(bobTable))
+		_, err = registry.Table(main.Principal{UserID: "carol", Tenant: "house-a"}, "a")
+		Expect(err).Should(MatchError(main.ErrDatasetNotFound))
+	})
+
+	It("lets viewers read but not share or overwrite", func() {
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(alice, "a", "Alice", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registry.Grant(alice, "a", bob, main.RoleViewer)).Should(Succeed())
+
+		got, err := registry.Table(bob, "a")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(got).Should(Equal(table))
+		Expect(registry.Grant(bob, "a", main.Principal{UserID: "carol"}, main.RoleViewer)).Should(MatchError(main.ErrPermissionDenied))
+		_, err = registry.Put(bob, "a", "Bob", table)
+		Expect(err).Should(MatchError(main.ErrPermissionDenied))
+	})
+
+	It("keys grants by tenant and user", func() {
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(alice, "a", "Alice", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registry.Grant(alice, "a", main.Principal{UserID: "bob"}, main.RoleViewer)).Should(Succeed())
+
+		// bob di rumah alice diberi akses, bob lain di rumah lain tidak
+		housemate := main.Principal{UserID: "bob", Tenant: "house-a"}
+		_, err = registry.Table(housemate, "a")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = registry.Table(bob, "a")
+		Expect(err).Should(MatchError(main.ErrDatasetNotFound))
+		Expect(registry.List(bob)).Should(BeEmpty())
+
+		Expect(registry.Grant(alice, "a", bob, main.RoleOwner)).Should(Succeed())
+		_, err = registry.Put(housemate, "a", "Housemate", table)
+		Expect(err).Should(MatchError(main.ErrPermissionDenied))
+		_, err = registry.Put(bob, "a", "Bob", table)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("enforces per-tenant quotas", func() {
+		registry := main.NewDatasetRegistry(main.Quota{MaxDatasets: 1})
+		registry.SetQuota("house-b", main.Quota{MaxRows: 1})
+
+		_, err := registry.Put(alice, "a1", "", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = registry.Put(alice, "a2", "", table)
+		Expect(err).Should(MatchError(main.ErrQuotaExceeded))
+		_, err = registry.Put(bob, "b1", "", table)
+		Expect(err).Should(MatchError(main.ErrQuotaExceeded))
+	})
+})
+
+var _ = Describe("Authenticator", func() {
+	It("accepts API keys and valid JWTs", func() {
+		auth := &main.MultiAuthenticator{
+			APIKeys: main.NewAPIKeyAuthenticator([]main.APIKeyEntry{{Key: "k-123", UserID: "alice", Tenant: "house-a"}}),
+			JWT:     &main.JWTAuthenticator{Secret: []byte("s3cret")},
+		}
+
+		p, err := auth.Authenticate("k-123")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(p).Should(Equal(main.Principal{UserID: "alice", Tenant: "house-a"}))
+
+		p, err = auth.Authenticate(signJWT("s3cret", `{"sub":"bob","tenant":"house-b"}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(p.Tenant).Should(Equal("house-b"))
+
+		_, err = auth.Authenticate(signJWT("wrong", `{"sub":"bob"}`))
+		Expect(err).Should(MatchError(main.ErrUnauthenticated))
+	})
+
+	It("looks up the tenant of a user in the API key file", func() {
+		keys := main.NewAPIKeyAuthenticator([]main.APIKeyEntry{
+			{Key: "k-1", UserID: "alice", Tenant: "rumah-a"},
+			{Key: "k-2", UserID: "alice", Tenant: "rumah-b"},
+			{Key: "k-3", UserID: "bob"},
+		})
+		tenant, _ := keys.TenantOf("alice")
+		Expect(tenant).Should(Equal("rumah-a"))
+		tenant, _ = keys.TenantOf("bob")
+		Expect(tenant).Should(Equal("bob"))
+		_, ok := keys.TenantOf("carol")
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("rejects expired JWTs", func() {
+		auth := &main.JWTAuthenticator{Secret: []byte("s3cret"), Now: func() time.Time { return time.Unix(2000, 0) }}
+		_, err := auth.Authenticate(signJWT("s3cret", `{"sub":"bob","exp":1000}`))
+		Expect(err).Should(MatchError(main.ErrUnauthenticated))
+	})
+})
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// replSession struct state REPL selama satu sesi
+type replSession struct {
+	pipeline *Pipeline
+	table    map[string][]string
+	out      io.Writer
+	// lastID id history dari jawaban terakhir, untuk :correct dan :wrong
+	lastID string
+	// last hasil terakhir yang berhasil, untuk :explain
+	last *Result
+	// queries pertanyaan yang sudah dijawab sejak :reset, dikirim ke model SQA sebagai percakapan
+	queries []string
+	// context cell jawaban terakhir dalam indeks baris dan kolom s.table, untuk TAPAS lokal
+	context [][]int
+	// suggestions menu terakhir dari :suggest, dipilih dengan mengetik nomornya
+	suggestions []Suggestion
+	// filter dari :filter, diterapkan ke setiap pertanyaan sampai dihapus
+	filter string
+	// ctx dibatalkan saat shutdown sehingga pertanyaan yang sedang berjalan ikut berhenti
+	ctx context.Context
+}
+
+// RunREPL menjalankan interaksi chatbot di terminal sampai user mengetik exit, input habis (EOF,
+// misalnya Ctrl-D), atau ctx dibatalkan oleh SIGINT/SIGTERM. Pertanyaan yang sedang berjalan
+// ikut dibatalkan lewat ctx.
+func RunREPL(ctx context.Context, pipeline *Pipeline, table map[string][]string, in io.Reader, out io.Writer) {
+	session := &replSession{pipeline: pipeline, table: table, out: out, ctx: ctx}
+	fmt.Fprintln(out, T("repl.banner"))
+	fmt.Fprintln(out, T("repl.hint"))
+
+	// Input dibaca di goroutine terpisah agar menunggu input tidak menghalangi shutdown
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		fmt.Fprint(out, T("repl.prompt"))
+		var query string
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return
+		case line, ok := <-lines:
+			if !ok {
+				fmt.Fprintln(out)
+				return
+			}
+			query = line
+		}
+
+		// "exit" selalu diterima, selain kata keluar dari locale aktif
+		if word := strings.ToLower(strings.TrimSpace(query)); word == "exit" || word == T("repl.exit_word") {
+			break
+		}
+
+		// Perintah REPL diawali dengan ':'
+		if strings.HasPrefix(strings.TrimSpace(query), ":") {
+			session.runCommand(strings.TrimSpace(query))
+			continue
+		}
+
+		// Nomor dari menu :suggest diganti dengan pertanyaannya
+		if n, err := strconv.Atoi(strings.TrimSpace(query)); err == nil && n >= 1 && n <= len(session.suggestions) {
+			query = session.suggestions[n-1].Text
+			fmt.Fprintln(out, T("repl.prompt")+query)
+		}
+
+		session.ask(query)
+	}
+}
+
+func (s *replSession) ask(query string) {
+	q := Question{DatasetID: DefaultDatasetID, Table: s.table, Query: query, Filter: s.filter}
+	// Mode SQA: jawaban sebelumnya menjadi konteks pertanyaan berikutnya sampai :reset
+	if s.pipeline.Sequential {
+		q.PrevQueries, q.PrevCoordinates = s.queries, s.context
+	}
+	result, err := s.pipeline.Ask(s.ctx, q)
+	if err != nil {
+		log.Println(T("repl.ask_error", err))
+		return
+	}
+	s.lastID = result.ID
+	s.last = &result
+	s.context = result.SourceCoordinates()
+	s.queries = append(s.queries, result.Query)
+
+	// Tampilkan respons dalam bahasa yang dipakai user, pertanyaan bahasa Inggris memakai locale aktif
+	response := result.Response
+	lang := CurrentLocale()
+	if result.Language != LangEnglish {
+		lang = result.Language
+		fmt.Fprintln(s.out, Tl(lang, "report.translated", result.Query))
+	} else if len(result.Corrections) > 0 {
+		fmt.Fprintln(s.out, Tl(lang, "report.normalized", result.Query))
+	}
+	fmt.Fprintln(s.out, Tl(lang, "report.answer", result.Answer))
+	if value, ok := ComputeAnswer(response); ok && result.Unit != "" {
+		fmt.Fprintln(s.out, Tl(lang, "report.value", LocalizeText(value, lang), result.Unit))
+	}
+	fmt.Fprintln(s.out, Tl(lang, "report.coordinates", response.Coordinates))
+	fmt.Fprintln(s.out, Tl(lang, "report.cells", response.Cells))
+	fmt.Fprintln(s.out, Tl(lang, "report.aggregator", response.Aggregator))
+	fmt.Fprintln(s.out)
+}
+
+// runCommand menjalankan perintah REPL seperti ":history search kitchen"
+func (s *replSession) runCommand(line string) {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case ":history":
+		if s.pipeline.History == nil {
+			fmt.Fprintln(s.out, T("repl.history_off"))
+			return
+		}
+		q := HistoryQuery{Limit: 10}
+		if len(fields) > 1 && fields[1] == "search" {
+			q.Text = strings.Join(fields[2:], " ")
+			q.Limit = 0
+		}
+		entries := s.pipeline.History.Search(q)
+		if len(entries) == 0 {
+			fmt.Fprintln(s.out, T("repl.history_empty"))
+		}
+		for _, e := range entries {
+			printHistoryEntry(s.out, e)
+		}
+	case ":suggest":
+		s.suggestions = SuggestQuestions(s.table, 10)
+		for i, suggestion := range s.suggestions {
+			fmt.Fprintf(s.out, "%2d. %s\n", i+1, suggestion.Text)
+		}
+		fmt.Fprintln(s.out, T("repl.suggest_hint"))
+	case ":compute":
+		table, err := applyComputedColumn(s.table, strings.TrimSpace(strings.TrimPrefix(line, fields[0])))
+		if err != nil {
+			fmt.Fprintln(s.out, T("repl.compute_error", err))
+			return
+		}
+		// Kolom baru menggeser indeks kolom urut abjad, jadi konteks SQA ikut diterjemahkan
+		s.context = remapColumns(s.context, sortedColumns(s.table), sortedColumns(table))
+		s.table = table
+		fmt.Fprintln(s.out, T("repl.columns", strings.Join(sortedColumns(s.table), ", ")))
+	case ":filter":
+		src := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+		if src == "" {
+			s.filter = ""
+			fmt.Fprintln(s.out, T("repl.filter_cleared"))
+			return
+		}
+		_, rows, err := ApplyFilter(s.table, src)
+		if err != nil {
+			fmt.Fprintln(s.out, T("repl.filter_error", err))
+			return
+		}
+		s.filter = src
+		fmt.Fprintln(s.out, T("repl.filter_set", len(rows), tableRows(s.table)))
+	case ":columns":
+		fmt.Fprintln(s.out, T("repl.columns", strings.Join(sortedColumns(s.table), ", ")))
+	case ":reset":
+		s.last = nil
+		s.queries, s.context = nil, nil
+		fmt.Fprintln(s.out, T("repl.reset"))
+	case ":explain":
+		if s.last == nil {
+			fmt.Fprintln(s.out, T("repl.no_explanation"))
+			return
+		}
+		lang := CurrentLocale()
+		if s.last.Language != LangEnglish {
+			lang = s.last.Language
+		}
+		PrintExplanation(s.out, s.last.Explanation, lang)
+	case ":correct", ":wrong":
+		if s.lastID == "" {
+			fmt.Fprintln(s.out, T("repl.no_answer_yet"))
+			return
+		}
+		correct := fields[0] == ":correct"
+		expected := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+		ex, err := s.pipeline.RecordFeedback("", s.lastID, correct, expected)
+		if err != nil {
+			fmt.Fprintln(s.out, T("repl.feedback_error", err))
+			return
+		}
+		fmt.Fprintln(s.out, T("repl.feedback_saved", ex.Intent, ex.Expected))
+	default:
+		fmt.Fprintln(s.out, T("repl.unknown_command", fields[0]))
+	}
+}
+
+func printHistoryEntry(out io.Writer, e HistoryEntry) {
+	answer := e.Response.Answer
+	if e.Error != "" {
+		answer = T("report.error", e.Error)
+	}
+	fmt.Fprintln(out, T("report.history", e.Time.Format("2006-01-02 15:04:05"), e.Question, answer, e.Backend, e.LatencyMs))
+	for _, f := range e.Filters {
+		fmt.Fprintln(out, T("report.filter", f))
+	}
+	if e.Feedback != nil {
+		fmt.Fprintln(out, T("report.feedback", e.Feedback.Correct, e.Feedback.Expected))
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// setupServerAuth mengaktifkan autentikasi dan mendaftarkan dataset CSV ke registry. Tenant
+// defaultOwner yang kosong diambil dari entry user itu di file API key, atau sama dengan UserID.
+func setupServerAuth(server *TableQAServer, apiKeysFile string, defaultOwner Principal, csvFile string, table map[string][]string) error {
+	// Autentikasi aktif jika ada file API key atau JWT_SECRET
+	owner := LocalPrincipal
+	auth := &MultiAuthenticator{}
+	if apiKeysFile != "" {
+		keys, err := LoadAPIKeys(apiKeysFile)
+		if err != nil {
+			return err
+		}
+		auth.APIKeys = keys
+	}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		auth.JWT = &JWTAuthenticator{Secret: []byte(secret)}
+	}
+	if auth.APIKeys != nil || auth.JWT != nil {
+		server.Auth = auth
+		owner = defaultOwner
+		if owner.Tenant == "" && auth.APIKeys != nil {
+			owner.Tenant, _ = auth.APIKeys.TenantOf(owner.UserID)
+		}
+		if owner.Tenant == "" {
+			owner.Tenant = owner.UserID
+		}
+	}
+	if server.Auth == nil || defaultOwner.UserID != "" {
+		if _, err := server.Registry.Put(owner, DefaultDatasetID, csvFile, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunServers menjalankan server gRPC dan/atau HTTP sampai salah satunya error atau ctx dibatalkan.
+// Saat ctx dibatalkan kedua server bersamaan berhenti menerima request baru dan menunggu request
+// yang sedang berjalan paling lama drain; setelah itu request yang tersisa dibatalkan. Jika salah
+// satu server error, server lainnya dihentikan dengan cara yang sama sebelum error dikembalikan.
+func RunServers(ctx context.Context, server *TableQAServer, grpcAddr, httpAddr string, drain time.Duration) error {
+	errc := make(chan error, 2)
+	// requests context dasar semua request HTTP, dibatalkan jika drain habis
+	requests, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	var grpcServer *grpc.Server
+	if grpcAddr != "" {
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return err
+		}
+		log.Println(T("server.grpc_listening", lis.Addr()))
+		var grpcErrc <-chan error
+		grpcServer, grpcErrc = ServeGRPC(lis, server)
+		go func() { errc <- <-grpcErrc }()
+	}
+
+	var api *http.Server
+	if httpAddr != "" {
+		api = &http.Server{
+			Addr:        httpAddr,
+			Handler:     NewAPIServer(server).Handler(),
+			BaseContext: func(net.Listener) context.Context { return requests },
+		}
+		log.Println(T("server.http_listening", httpAddr))
+		go func() { errc <- api.ListenAndServe() }()
+	}
+
+	var serveErr error
+	select {
+	case serveErr = <-errc:
+	case <-ctx.Done():
+	}
+
+	log.Println(T("server.shutting_down", drain))
+	deadline, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	// HTTP dan gRPC di-drain bersamaan agar tidak ada yang tetap menerima request selama yang lain menunggu
+	var wg sync.WaitGroup
+	if api != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := api.Shutdown(deadline); err != nil {
+				// Drain habis: batalkan request yang tersisa termasuk panggilan ke model
+				cancelRequests()
+				api.Close()
+			}
+		}()
+	}
+	if grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			grpcServer.GracefulStop()
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-deadline.Done():
+		cancelRequests()
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		<-done
+	}
+	return serveErr
+}
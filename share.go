@@ -0,0 +1,340 @@
+package main
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidShare dikembalikan jika opsi atau file agregat bersama tidak valid
+var ErrInvalidShare = messageError("error.invalid_share")
+
+// shareVersion versi format file SharedExport
+const shareVersion = 1
+
+// ApplianceCategories kategori yang selalu ada di setiap bulan file bersama, termasuk yang nol,
+// agar daftar kategori sendiri tidak membocorkan peralatan yang dimiliki rumah
+var ApplianceCategories = []string{"cooling", "heating", "kitchen", "laundry", "entertainment", "lighting", "other"}
+
+// applianceKeywords kata di nama peralatan untuk menentukan kategorinya
+var applianceKeywords = []struct {
+	keyword  string
+	category string
+}{
+	{"refrigerator", "cooling"}, {"fridge", "cooling"}, {"freezer", "cooling"}, {"air conditioner", "cooling"}, {"ac", "cooling"}, {"fan", "cooling"},
+	{"heater", "heating"}, {"boiler", "heating"}, {"heat pump", "heating"},
+	{"microwave", "kitchen"}, {"oven", "kitchen"}, {"stove", "kitchen"}, {"dishwasher", "kitchen"}, {"kettle", "kitchen"}, {"rice cooker", "kitchen"},
+	{"washing machine", "laundry"}, {"washer", "laundry"}, {"dryer", "laundry"}, {"iron", "laundry"},
+	{"tv", "entertainment"}, {"television", "entertainment"}, {"computer", "entertainment"}, {"laptop", "entertainment"}, {"console", "entertainment"},
+	{"light", "lighting"}, {"lamp", "lighting"},
+}
+
+// ApplianceCategory mengelompokkan nama peralatan, misalnya "Water Heater" menjadi "heating"
+func ApplianceCategory(appliance string) string {
+	words := " " + strings.Join(strings.Fields(strings.ToLower(appliance)), " ") + " "
+	for _, k := range applianceKeywords {
+		if strings.Contains(words, " "+k.keyword+" ") {
+			return k.category
+		}
+	}
+	return "other"
+}
+
+// ShareOptions struct pengaturan ExportShared
+type ShareOptions struct {
+	// Epsilon anggaran privasi; makin kecil makin banyak noise
+	Epsilon float64
+	// Clip batas konsumsi satu baris dalam kWh. Baris yang lebih besar dipotong sehingga satu
+	// baris paling banyak mengubah satu agregat sebesar Clip (sensitivitas mekanisme Laplace)
+	Clip float64
+	// Profile jenis rumah untuk membandingkan dengan rumah serupa, misalnya "apartment-2br"
+	Profile string
+	// Rand sumber acak noise, nil berarti crypto/rand. Sumber yang bisa ditebak (misalnya seed dari
+	// waktu export) membuat noise bisa dihapus, jadi isi hanya untuk test.
+	Rand *rand.Rand
+}
+
+// SharedAggregate struct konsumsi satu kategori peralatan dalam satu bulan
+type SharedAggregate struct {
+	Month    string  `json:"month"`
+	Category string  `json:"category"`
+	Energy   float64 `json:"energy_kwh"`
+}
+
+// SharedExport struct file agregat yang aman dibagikan ke tetangga
+type SharedExport struct {
+	Version    int               `json:"version"`
+	Profile    string            `json:"profile,omitempty"`
+	Epsilon    float64           `json:"epsilon"`
+	Clip       float64           `json:"clip_kwh"`
+	Aggregates []SharedAggregate `json:"aggregates"`
+}
+
+// MonthlyCategoryTotals menjumlahkan Energy_Consumption per bulan dan kategori peralatan tanpa
+// noise. Setiap bulan berisi semua ApplianceCategories. Nilai di atas clip dipotong jika clip > 0.
+func MonthlyCategoryTotals(table map[string][]string, clip float64) ([]SharedAggregate, error) {
+	if err := requireColumns(InferSchema(table), "Date", "Appliance", "Energy_Consumption"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidShare, err)
+	}
+	totals := make(map[[2]string]float64)
+	months := make(map[string]bool)
+	for i, date := range table["Date"] {
+		t, err := time.Parse(dateLayout, strings.TrimSpace(date))
+		if err != nil {
+			continue
+		}
+		energy, err := strconv.ParseFloat(strings.TrimSpace(table["Energy_Consumption"][i]), 64)
+		if err != nil || energy < 0 {
+			continue
+		}
+		if clip > 0 && energy > clip {
+			energy = clip
+		}
+		month := t.Format("2006-01")
+		months[month] = true
+		totals[[2]string{month, ApplianceCategory(table["Appliance"][i])}] += energy
+	}
+
+	sortedMonths := make([]string, 0, len(months))
+	for month := range months {
+		sortedMonths = append(sortedMonths, month)
+	}
+	sort.Strings(sortedMonths)
+	var aggregates []SharedAggregate
+	for _, month := range sortedMonths {
+		for _, category := range ApplianceCategories {
+			aggregates = append(aggregates, SharedAggregate{Month: month, Category: category, Energy: totals[[2]string{month, category}]})
+		}
+	}
+	return aggregates, nil
+}
+
+// ExportShared menghitung agregat bulanan per kategori dengan noise Laplace (skala Clip/Epsilon).
+// Setiap baris hanya masuk ke satu agregat sehingga seluruh file memenuhi Epsilon-differential
+// privacy terhadap satu baris pembacaan. Nilai noise bisa negatif; nilai itu sengaja tidak
+// dipotong agar rata-rata banyak rumah tetap tidak bias.
+func ExportShared(table map[string][]string, opts ShareOptions) (SharedExport, error) {
+	if opts.Epsilon <= 0 || opts.Clip <= 0 {
+		return SharedExport{}, fmt.Errorf("%w: %s", ErrInvalidShare, T("share.epsilon_clip"))
+	}
+	aggregates, err := MonthlyCategoryTotals(table, opts.Clip)
+	if err != nil {
+		return SharedExport{}, err
+	}
+	rng := opts.Rand
+	if rng == nil {
+		rng = rand.New(cryptoSource{})
+	}
+	scale := opts.Clip / opts.Epsilon
+	for i := range aggregates {
+		aggregates[i].Energy = math.Round((aggregates[i].Energy+laplace(rng, scale))*100) / 100
+	}
+	return SharedExport{Version: shareVersion, Profile: opts.Profile, Epsilon: opts.Epsilon, Clip: opts.Clip, Aggregates: aggregates}, nil
+}
+
+// cryptoSource rand.Source64 yang membaca crypto/rand untuk noise Laplace
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		// Tanpa sumber acak yang aman export tidak boleh berjalan dengan noise yang bisa ditebak
+		panic(err)
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (s cryptoSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+func (cryptoSource) Seed(int64) {}
+
+// laplace mengambil satu sampel distribusi Laplace(0, scale) dengan inverse CDF
+func laplace(rng *rand.Rand, scale float64) float64 {
+	u := rng.Float64() - 0.5
+	if u == -0.5 {
+		return 0
+	}
+	sign := 1.0
+	if u < 0 {
+		sign = -1
+	}
+	return -scale * sign * math.Log(1-2*math.Abs(u))
+}
+
+// LoadShared membaca file agregat bersama dan memeriksa versinya
+func LoadShared(r io.Reader) (SharedExport, error) {
+	var export SharedExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return SharedExport{}, fmt.Errorf("%w: %v", ErrInvalidShare, err)
+	}
+	if export.Version != shareVersion {
+		return SharedExport{}, fmt.Errorf("%w: %s", ErrInvalidShare, T("share.unsupported_version", export.Version))
+	}
+	return export, nil
+}
+
+// ShareComparison struct perbandingan konsumsi rumah sendiri dengan rata-rata rumah lain
+type ShareComparison struct {
+	Month       string  `json:"month"`
+	Category    string  `json:"category"`
+	Local       float64 `json:"local_kwh"`
+	PoolAverage float64 `json:"pool_average_kwh"`
+	Homes       int     `json:"homes"`
+	// Difference selisih relatif terhadap rata-rata, 0.25 berarti 25% di atas rata-rata
+	Difference float64 `json:"difference"`
+}
+
+// CompareShared membandingkan agregat lokal (tanpa noise) dengan kumpulan file tetangga.
+// Jika profile tidak kosong hanya file dengan profile yang sama yang dipakai. Kategori yang
+// kosong di rumah sendiri dan di semua rumah lain dilewati.
+func CompareShared(local []SharedAggregate, pool []SharedExport, profile string) []ShareComparison {
+	type sum struct {
+		total float64
+		homes int
+	}
+	sums := make(map[[2]string]*sum)
+	for _, export := range pool {
+		if profile != "" && export.Profile != profile {
+			continue
+		}
+		for _, a := range export.Aggregates {
+			key := [2]string{a.Month, a.Category}
+			if sums[key] == nil {
+				sums[key] = &sum{}
+			}
+			sums[key].total += a.Energy
+			sums[key].homes++
+		}
+	}
+
+	var comparisons []ShareComparison
+	for _, a := range local {
+		s := sums[[2]string{a.Month, a.Category}]
+		if s == nil {
+			continue
+		}
+		c := ShareComparison{Month: a.Month, Category: a.Category, Local: a.Energy, PoolAverage: s.total / float64(s.homes), Homes: s.homes}
+		if a.Energy == 0 && c.PoolAverage <= 0 {
+			continue
+		}
+		if c.PoolAverage > 0 {
+			c.Difference = (c.Local - c.PoolAverage) / c.PoolAverage
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// PrintShareComparison menampilkan hasil CompareShared dalam bentuk tabel
+func PrintShareComparison(out io.Writer, comparisons []ShareComparison) {
+	fmt.Fprintf(out, "%-8s %-14s %10s %10s %6s %8s\n", T("share.month"), T("share.category"), T("share.local"), T("share.average"), T("share.homes"), T("share.diff"))
+	for _, c := range comparisons {
+		diff := "-"
+		if c.PoolAverage > 0 {
+			diff = fmt.Sprintf("%+.0f%%", c.Difference*100)
+		}
+		fmt.Fprintf(out, "%-8s %-14s %10.2f %10.2f %6d %8s\n", c.Month, c.Category, c.Local, c.PoolAverage, c.Homes, diff)
+	}
+}
+
+// runShare menjalankan perintah "share":
+//
+//	go run . share export -epsilon 1 -clip 5 -profile apartment-2br -out home.json
+//	go run . share compare -pool neighbors/ -profile apartment-2br
+func runShare(args []string, cfg Config) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidShare, T("share.usage"))
+	}
+	fs := flag.NewFlagSet("share "+args[0], flag.ExitOnError)
+	csvFile := fs.String("csv", cfg.CSV, "household table to aggregate (default: the profile's csv)")
+	profile := fs.String("profile", "", "kind of home, only neighbors with the same profile are compared")
+
+	switch args[0] {
+	case "export":
+		epsilon := fs.Float64("epsilon", 1, "privacy budget for the whole file (smaller = more noise)")
+		clip := fs.Float64("clip", 5, "maximum kWh a single reading can contribute")
+		outFile := fs.String("out", "", "write the shared aggregates to this file (default stdout)")
+		fs.Parse(args[1:])
+		table, err := readCSVTable(*csvFile)
+		if err != nil {
+			return err
+		}
+		export, err := ExportShared(table, ShareOptions{Epsilon: *epsilon, Clip: *clip, Profile: *profile})
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return err
+		}
+		if *outFile == "" {
+			_, err = fmt.Println(string(data))
+			return err
+		}
+		return ioutil.WriteFile(*outFile, data, 0o644)
+
+	case "compare":
+		poolPath := fs.String("pool", "neighbors", "directory or comma-separated list of shared aggregate files")
+		fs.Parse(args[1:])
+		table, err := readCSVTable(*csvFile)
+		if err != nil {
+			return err
+		}
+		local, err := MonthlyCategoryTotals(table, 0)
+		if err != nil {
+			return err
+		}
+		pool, err := loadSharedPool(*poolPath)
+		if err != nil {
+			return err
+		}
+		PrintShareComparison(os.Stdout, CompareShared(local, pool, *profile))
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidShare, T("share.unknown_command", args[0]))
+}
+
+// loadSharedPool membaca semua file *.json di sebuah direktori atau daftar file dipisah koma
+func loadSharedPool(path string) ([]SharedExport, error) {
+	files := strings.Split(path, ",")
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+	var pool []SharedExport
+	for _, file := range files {
+		f, err := os.Open(strings.TrimSpace(file))
+		if err != nil {
+			return nil, err
+		}
+		export, err := LoadShared(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		pool = append(pool, export)
+	}
+	return pool, nil
+}
+
+// readCSVTable membaca file CSV menjadi tabel
+func readCSVTable(path string) (map[string][]string, error) {
+	data, err := ReadDataFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return CsvToSlice(string(data))
+}
@@ -0,0 +1,107 @@
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Differentially private sharing", func() {
+	table := map[string][]string{
+		"Date":               {"2022-01-01", "2022-01-02", "2022-01-02", "2022-02-01", "2022-02-03", "not a date"},
+		"Appliance":          {"Refrigerator", "TV", "Water Heater", "Refrigerator", "Refrigerator", "TV"},
+		"Energy_Consumption": {"1.2", "0.5", "12", "1.1", "", "3"},
+	}
+
+	It("groups appliances into categories", func() {
+		Expect(main.ApplianceCategory("Refrigerator")).Should(Equal("cooling"))
+		Expect(main.ApplianceCategory("Water  Heater")).Should(Equal("heating"))
+		Expect(main.ApplianceCategory("Smart TV")).Should(Equal("entertainment"))
+		Expect(main.ApplianceCategory("Actuator")).Should(Equal("other"))
+	})
+
+	It("totals every category per month, clipping large readings", func() {
+		totals, err := main.MonthlyCategoryTotals(table, 5)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(totals).Should(HaveLen(2 * len(main.ApplianceCategories)))
+		Expect(totals).Should(ContainElements(
+			main.SharedAggregate{Month: "2022-01", Category: "cooling", Energy: 1.2},
+			main.SharedAggregate{Month: "2022-01", Category: "heating", Energy: 5},
+			main.SharedAggregate{Month: "2022-02", Category: "entertainment", Energy: 0},
+		))
+
+		_, err = main.MonthlyCategoryTotals(map[string][]string{"Date": {"2022-01-01"}}, 0)
+		Expect(err).Should(MatchError(main.ErrInvalidShare))
+	})
+
+	It("adds Laplace noise scaled by clip and epsilon", func() {
+		totals, _ := main.MonthlyCategoryTotals(table, 5)
+		precise, err := main.ExportShared(table, main.ShareOptions{Epsilon: 1e6, Clip: 5, Profile: "apartment", Rand: rand.New(rand.NewSource(1))})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(precise.Profile).Should(Equal("apartment"))
+		for i, a := range precise.Aggregates {
+			Expect(a.Energy).Should(BeNumerically("~", totals[i].Energy, 0.01))
+		}
+
+		// Rata-rata noise banyak sampel mendekati nol dan simpangannya sekitar sqrt(2) * clip / epsilon
+		var sum, squares float64
+		n := 0
+		for seed := int64(0); seed < 200; seed++ {
+			noisy, err := main.ExportShared(table, main.ShareOptions{Epsilon: 0.5, Clip: 5, Rand: rand.New(rand.NewSource(seed))})
+			Expect(err).ShouldNot(HaveOccurred())
+			for i, a := range noisy.Aggregates {
+				d := a.Energy - totals[i].Energy
+				sum += d
+				squares += d * d
+				n++
+			}
+		}
+		Expect(sum / float64(n)).Should(BeNumerically("~", 0, 1))
+		Expect(math.Sqrt(squares / float64(n))).Should(BeNumerically("~", math.Sqrt2*10, 2))
+
+		_, err = main.ExportShared(table, main.ShareOptions{Epsilon: 0, Clip: 5})
+		Expect(err).Should(MatchError(main.ErrInvalidShare))
+	})
+
+	It("compares the household with neighbors of the same profile", func() {
+		export, err := main.ExportShared(table, main.ShareOptions{Epsilon: 1, Clip: 5, Profile: "apartment"})
+		Expect(err).ShouldNot(HaveOccurred())
+		data, _ := json.Marshal(export)
+		loaded, err := main.LoadShared(bytes.NewReader(data))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.Aggregates).Should(Equal(export.Aggregates))
+		_, err = main.LoadShared(strings.NewReader(`{"version": 9}`))
+		Expect(err).Should(MatchError(ContainSubstring("unsupported version")))
+
+		neighbor := func(profile string, cooling float64) main.SharedExport {
+			return main.SharedExport{Version: 1, Profile: profile, Aggregates: []main.SharedAggregate{
+				{Month: "2022-01", Category: "cooling", Energy: cooling},
+				{Month: "2022-01", Category: "lighting", Energy: 0.4},
+			}}
+		}
+		pool := []main.SharedExport{neighbor("apartment", 0.8), neighbor("apartment", 1.2), neighbor("house", 9)}
+		local, _ := main.MonthlyCategoryTotals(table, 0)
+
+		comparisons := main.CompareShared(local, pool, "apartment")
+		Expect(comparisons).Should(HaveLen(2))
+		Expect(comparisons[0].Category).Should(Equal("cooling"))
+		Expect(comparisons[0].Homes).Should(Equal(2))
+		Expect(comparisons[0].PoolAverage).Should(BeNumerically("~", 1.0, 1e-9))
+		Expect(comparisons[0].Difference).Should(BeNumerically("~", 0.2, 1e-9))
+		Expect(comparisons[1].Category).Should(Equal("lighting"))
+		Expect(comparisons[1].Difference).Should(BeNumerically("~", -1, 1e-9))
+
+		Expect(main.CompareShared(local, pool, "")[0].Homes).Should(Equal(3))
+
+		var out bytes.Buffer
+		main.PrintShareComparison(&out, comparisons)
+		Expect(out.String()).Should(ContainSubstring("+20%"))
+	})
+})
@@ -0,0 +1,155 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// blockingBackend menunggu sampai ctx dibatalkan, seperti panggilan model yang lama
+type blockingBackend struct {
+	started chan struct{}
+}
+
+func (b *blockingBackend) Name() string { return "blocking" }
+
+func (b *blockingBackend) Ask(ctx context.Context, payload main.Inputs) (main.Response, error) {
+	close(b.started)
+	<-ctx.Done()
+	return main.Response{}, ctx.Err()
+}
+
+var _ = Describe("Graceful shutdown", func() {
+	table := map[string][]string{
+		"Appliance":          {"TV", "Fridge"},
+		"Energy_Consumption": {"0.5", "1.2"},
+	}
+
+	runREPL := func(ctx context.Context, pipeline *main.Pipeline, in io.Reader, out io.Writer) <-chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			main.RunREPL(ctx, pipeline, table, in, out)
+		}()
+		return done
+	}
+
+	It("leaves the REPL at the end of input", func() {
+		var out bytes.Buffer
+		done := runREPL(context.Background(), &main.Pipeline{Backend: &fakeBackend{}}, strings.NewReader(""), &out)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("leaves the REPL when the context is cancelled while waiting for input", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		in, w := io.Pipe()
+		defer w.Close()
+		done := runREPL(ctx, &main.Pipeline{Backend: &fakeBackend{}}, in, io.Discard)
+		Consistently(done, 100*time.Millisecond).ShouldNot(BeClosed())
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("cancels the question in flight", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		backend := &blockingBackend{started: make(chan struct{})}
+		in, w := io.Pipe()
+		defer w.Close()
+		done := runREPL(ctx, &main.Pipeline{Backend: backend}, in, io.Discard)
+		go w.Write([]byte("How much energy did the TV use?\n"))
+		Eventually(backend.started).Should(BeClosed())
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("stops a batch before the next question and keeps the answers so far", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		backend := &fakeBackend{response: main.Response{Answer: "0.5", Aggregator: "NONE"}}
+		var out bytes.Buffer
+		err := main.RunBatch(ctx, &main.Pipeline{Backend: backend}, table, "", 1, strings.NewReader("How much?\nWhich one?\n"), &out)
+		Expect(err).Should(MatchError(context.Canceled))
+		Expect(backend.asked).Should(BeEmpty())
+	})
+
+	freeAddr := func() string {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer lis.Close()
+		return lis.Addr().String()
+	}
+	newServer := func(backend main.Backend) *main.TableQAServer {
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(main.LocalPrincipal, main.DefaultDatasetID, "", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		return main.NewTableQAServer(&main.Pipeline{Backend: backend}, registry)
+	}
+
+	It("stops accepting gRPC connections while HTTP requests drain", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		backend := &blockingBackend{started: make(chan struct{})}
+		grpcAddr, httpAddr := freeAddr(), freeAddr()
+		done := make(chan error, 1)
+		go func() { done <- main.RunServers(ctx, newServer(backend), grpcAddr, httpAddr, 2*time.Second) }()
+
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", grpcAddr)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).Should(Succeed())
+		go http.Post("http://"+httpAddr+"/v1/ask", "application/json", strings.NewReader(`{"query":"How much energy?"}`))
+		Eventually(backend.started).Should(BeClosed())
+
+		cancel()
+		// Request HTTP masih berjalan sampai drain habis, tetapi gRPC sudah menolak koneksi baru
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", grpcAddr)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}, time.Second).Should(HaveOccurred())
+		Consistently(done, 500*time.Millisecond).ShouldNot(Receive())
+		Eventually(done, 3*time.Second).Should(Receive(BeNil()))
+	})
+
+	It("stops the gRPC server when the HTTP listener fails", func() {
+		busy, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer busy.Close()
+		grpcAddr := freeAddr()
+
+		err = main.RunServers(context.Background(), newServer(&fakeBackend{}), grpcAddr, busy.Addr().String(), time.Second)
+		Expect(err).Should(HaveOccurred())
+		lis, err := net.Listen("tcp", grpcAddr)
+		Expect(err).ShouldNot(HaveOccurred())
+		lis.Close()
+	})
+
+	It("flushes history when it is closed", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "history.jsonl")
+		history, err := main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		id, err := history.Record(main.HistoryEntry{Question: "How much?", Backend: "fake"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(history.Close()).Should(Succeed())
+		Expect(history.Close()).Should(Succeed())
+
+		history, err = main.OpenHistory(path, main.Retention{})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer history.Close()
+		Expect(history.Get(id)).Should(HaveField("Question", "How much?"))
+	})
+})
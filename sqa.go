@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// sequentialInputs inputs endpoint table-QA Huggingface untuk model SQA: semua pertanyaan percakapan
+// dikirim berurutan dan model menjawabnya satu per satu dengan jawaban sebelumnya sebagai konteks
+type sequentialInputs struct {
+	Table map[string][]string `json:"table"`
+	Query []string            `json:"query"`
+}
+
+// sequentialParameters parameters endpoint table-QA; sequential menjawab daftar query sebagai satu
+// percakapan (model SQA)
+type sequentialParameters struct {
+	Sequential bool `json:"sequential"`
+}
+
+// sequentialRequest payload `{"inputs": {...}, "parameters": {"sequential": true}}`
+type sequentialRequest struct {
+	Inputs     sequentialInputs     `json:"inputs"`
+	Parameters sequentialParameters `json:"parameters"`
+}
+
+// newSequentialRequest menyusun payload SQA dari Inputs.PrevQueries ditambah pertanyaan sekarang
+func newSequentialRequest(payload Inputs) sequentialRequest {
+	queries := append(append([]string(nil), payload.PrevQueries...), payload.Query)
+	return sequentialRequest{Inputs: sequentialInputs{Table: payload.Table, Query: queries}, Parameters: sequentialParameters{Sequential: true}}
+}
+
+// decodeSequentialResponse mengambil jawaban pertanyaan terakhir dari daftar jawaban model SQA.
+// Jawaban tunggal juga diterima untuk endpoint yang hanya mengembalikan jawaban terakhir.
+func decodeSequentialResponse(data []byte) (Response, error) {
+	var answers []json.RawMessage
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return DecodeResponse(data)
+	}
+	if len(answers) == 0 {
+		return Response{}, errors.New(T("model.empty_sequential"))
+	}
+	return DecodeResponse(answers[len(answers)-1])
+}
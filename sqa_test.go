@@ -0,0 +1,132 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sequential QA", func() {
+	table := map[string][]string{
+		"Appliance":          {"TV", "Fridge", "Heater", "Fridge"},
+		"Date":               {"2023-05-31", "2023-06-01", "2023-06-02", "2023-07-01"},
+		"Energy_Consumption": {"0.5", "1.2", "2.3", "0.9"},
+		"Room":               {"Living Room", "Kitchen", "Bedroom", "Kitchen"},
+	}
+	var (
+		backend  *fakeBackend
+		pipeline *main.Pipeline
+		first    main.Result
+	)
+
+	BeforeEach(func() {
+		backend = &fakeBackend{response: main.Response{
+			Answer:      "Fridge, Heater",
+			Coordinates: [][]int{{0, 0}, {1, 0}},
+			Cells:       []string{"Fridge", "Heater"},
+			Aggregator:  "NONE",
+		}}
+		pipeline = &main.Pipeline{Backend: backend, Sequential: true}
+		var err error
+		first, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliances were used in June 2023?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[0].PrevCoordinates).Should(BeEmpty())
+	})
+
+	It("sends the previous answer in original row numbers with the follow-up", func() {
+		Expect(first.SourceCoordinates()).Should(Equal([][]int{{1, 0}, {2, 0}}))
+
+		_, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which of those were in the kitchen?", PrevCoordinates: first.SourceCoordinates()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[1].PrevCoordinates).Should(Equal([][]int{{1, 0}, {2, 0}}))
+	})
+
+	It("remaps previous cells onto a filtered table and drops rows that were not sent", func() {
+		prev := [][]int{{2, 0}, {3, 0}, {-1}}
+		_, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which of those were used on 2023-06-02?", PrevCoordinates: prev})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[1].PrevCoordinates).Should(Equal([][]int{{0, 0}}))
+	})
+
+	It("ignores previous cells outside sequential mode", func() {
+		pipeline.Sequential = false
+		_, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which of those were in the kitchen?", PrevCoordinates: first.SourceCoordinates()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[1].PrevCoordinates).Should(BeNil())
+	})
+
+	It("drops previous cells when the table is pre-aggregated", func() {
+		pipeline.PreAggregate = 3
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How does energy change over time?", PrevCoordinates: first.SourceCoordinates()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.PreAggregation).ShouldNot(BeEmpty())
+		Expect(backend.asked[1].PrevCoordinates).Should(BeNil())
+		Expect(result.SourceCoordinates()).Should(BeNil())
+	})
+
+	It("shifts previous columns in the REPL after :compute adds a column", func() {
+		backend.response = main.Response{Answer: "Kitchen", Coordinates: [][]int{{1, 3}}, Cells: []string{"Kitchen"}, Aggregator: "NONE"}
+		backend.asked = nil
+		in := strings.NewReader("Which room used the fridge?\n:compute Budget = Energy_Consumption * 2\nHow much energy was used there?\n")
+		main.RunREPL(context.Background(), pipeline, table, in, io.Discard)
+		Expect(backend.asked).Should(HaveLen(2))
+		// Budget masuk di antara Appliance dan Date sehingga Room bergeser dari kolom 3 ke 4
+		Expect(backend.asked[1].PrevCoordinates).Should(Equal([][]int{{1, 4}}))
+		Expect(backend.asked[1].PrevQueries).Should(Equal([]string{"Which room used the fridge?"}))
+	})
+	It("sends the conversation to Huggingface as sequential queries", func() {
+		var bodies []map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := ioutil.ReadAll(r.Body)
+			var body map[string]interface{}
+			json.Unmarshal(data, &body)
+			bodies = append(bodies, body)
+			if _, ok := body["inputs"]; ok {
+				w.Write([]byte(`[{"answer":"Fridge, Heater","coordinates":[[1,0],[2,0]],"cells":["Fridge","Heater"],"aggregator":"NONE"},` +
+					`{"answer":"Fridge","coordinates":[[1,0]],"cells":["Fridge"],"aggregator":"NONE"}]`))
+				return
+			}
+			w.Write([]byte(`{"answer":"Fridge, Heater","coordinates":[[1,0],[2,0]],"cells":["Fridge","Heater"],"aggregator":"NONE"}`))
+		}))
+		defer server.Close()
+
+		pipeline := &main.Pipeline{Backend: &main.HFBackend{Connector: &main.AIModelConnector{URL: server.URL}, Token: "token"}, Sequential: true}
+		small := map[string][]string{"Appliance": {"TV", "Fridge", "Heater"}, "Room": {"Living Room", "Kitchen", "Bedroom"}}
+		first, err := pipeline.Ask(context.Background(), main.Question{Table: small, Query: "Which appliances were used?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(bodies[0]).Should(HaveKeyWithValue("query", "Which appliances were used?"))
+		Expect(bodies[0]).ShouldNot(HaveKey("prev_coordinates"))
+
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: small, Query: "Which of those were in the kitchen?",
+			PrevQueries: []string{first.Query}, PrevCoordinates: first.SourceCoordinates()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(bodies[1]).Should(Equal(map[string]interface{}{
+			"inputs": map[string]interface{}{
+				"table": map[string]interface{}{"Appliance": []interface{}{"TV", "Fridge", "Heater"}, "Room": []interface{}{"Living Room", "Kitchen", "Bedroom"}},
+				"query": []interface{}{"Which appliances were used?", "Which of those were in the kitchen?"},
+			},
+			"parameters": map[string]interface{}{"sequential": true},
+		}))
+		// Jawaban terakhir dari daftar adalah jawaban pertanyaan lanjutan
+		Expect(result.Response.Cells).Should(Equal([]string{"Fridge"}))
+	})
+
+	It("answers sequential queries from the fake model in order", func() {
+		server := httptest.NewServer(main.FakeModelHandler())
+		defer server.Close()
+		connector := &main.AIModelConnector{URL: server.URL}
+		numbers := map[string][]string{"Energy_Consumption": {"0.5", "1.2", "2.3", "0.9"}}
+		resp, err := connector.ConnectAIModel(main.Inputs{Table: numbers, Query: "Which of those?", PrevQueries: []string{"Which used energy?"}}, "token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Cells).Should(Equal([]string{"0.5", "1.2", "2.3"}))
+	})
+})
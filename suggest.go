@@ -0,0 +1,214 @@
+package main
+
+import (
+	_ "embed"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed Cara_Pemakaian.md
+var usageGuide string
+
+// QuestionTemplate struct pertanyaan contoh dengan slot seperti {month}, {date}, {room}, {appliance}
+type QuestionTemplate struct {
+	Category string
+	Text     string
+}
+
+// Suggestion struct pertanyaan siap pakai yang slotnya sudah diisi dari dataset
+type Suggestion struct {
+	Text     string  `json:"text"`
+	Category string  `json:"category"`
+	Intent   string  `json:"intent"`
+	Score    float64 `json:"score"`
+}
+
+var (
+	guideLinePattern = regexp.MustCompile(`^\s*\d+\.\s*(?:Pertanyaan\s+(?:tentang\s+)?)?([^:]+):.*"([^"]+)"`)
+	monthSlotPattern = regexp.MustCompile(`(?i)\b(january|february|march|april|may|june|july|august|september|october|november|december) \d{4}\b`)
+	roomSlotPattern  = regexp.MustCompile(`(?i)\bthe (living room|kitchen|bedroom|bathroom|laundry room|garage)\b`)
+	slotPattern      = regexp.MustCompile(`\{(\w+)\}`)
+)
+
+// builtinTemplates pertanyaan tambahan untuk slot yang belum ada contohnya di Cara_Pemakaian.md
+var builtinTemplates = []QuestionTemplate{
+	{Category: "Konsumsi Energi per Peralatan", Text: "What is the total energy consumption of the {appliance}?"},
+	{Category: "Konsumsi Energi per Peralatan", Text: "How many times was the {appliance} on?"},
+	{Category: "Konsumsi Energi per Ruangan", Text: "What is the average energy consumption in the {room}?"},
+}
+
+// measureWords kata besaran di pertanyaan beserta kata di nama kolom yang bisa menjawabnya.
+// "power" di Cara_Pemakaian.md dipakai untuk konsumsi energi, jadi kolom energi juga diterima.
+var measureWords = map[string][]string{
+	"energy":  {"energy"},
+	"power":   {"power", "energy"},
+	"voltage": {"voltage"},
+}
+
+// ParseTemplates mengubah daftar contoh pertanyaan di Cara_Pemakaian.md menjadi template.
+// Bulan, tahun, tanggal, dan ruangan di contoh diganti slot berurutan ({month}, {month2}, ...).
+func ParseTemplates(guide string) []QuestionTemplate {
+	var templates []QuestionTemplate
+	for _, line := range strings.Split(guide, "\n") {
+		m := guideLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		text := strings.Replace(m[2], "[specific date]", "{date}", -1)
+		text = replaceWithSlots(monthSlotPattern, text, "month")
+		text = replaceWithSlots(yearPattern, text, "year")
+		text = replaceWithSlots(roomSlotPattern, text, "room")
+		templates = append(templates, QuestionTemplate{Category: strings.TrimSpace(m[1]), Text: text})
+	}
+	return templates
+}
+
+// replaceWithSlots mengganti setiap kecocokan menjadi {name}, {name2}, ... sesuai urutan
+func replaceWithSlots(pattern *regexp.Regexp, text, name string) string {
+	n := 0
+	return pattern.ReplaceAllStringFunc(text, func(m string) string {
+		n++
+		slot := "{" + name + "}"
+		if n > 1 {
+			slot = "{" + name + string(rune('0'+n)) + "}"
+		}
+		if strings.HasPrefix(strings.ToLower(m), "the ") {
+			return "the " + slot
+		}
+		return slot
+	})
+}
+
+// Templates mengembalikan template dari Cara_Pemakaian.md ditambah template bawaan
+func Templates() []QuestionTemplate {
+	return append(ParseTemplates(usageGuide), builtinTemplates...)
+}
+
+// SuggestQuestions mengisi slot template dari isi tabel lalu mengurutkannya berdasarkan relevansi.
+// Template yang slotnya tidak bisa diisi dibuang; limit 0 berarti semua.
+func SuggestQuestions(table map[string][]string, limit int) []Suggestion {
+	slots := templateSlots(table)
+	columns := strings.ToLower(strings.Join(sortedColumns(table), " "))
+
+	var suggestions []Suggestion
+	for _, t := range Templates() {
+		filled, score, ok := fillTemplate(t.Text, slots)
+		if !ok {
+			continue
+		}
+		lower := strings.ToLower(filled)
+		for word, columnWords := range measureWords {
+			if !strings.Contains(lower, word) {
+				continue
+			}
+			if containsAny(columns, columnWords) {
+				score++
+			} else {
+				score -= 2
+			}
+		}
+		// Tabel hanya berisi data historis, prediksi tidak bisa dijawab langsung
+		if strings.Contains(lower, "predicted") {
+			score--
+		}
+		suggestions = append(suggestions, Suggestion{Text: filled, Category: t.Category, Intent: ClassifyIntent(filled), Score: score})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Score > suggestions[j].Score })
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// fillTemplate mengisi slot; setiap slot yang terisi menambah skor karena pertanyaannya lebih spesifik
+func fillTemplate(text string, slots map[string][]string) (string, float64, bool) {
+	ok := true
+	score := 0.0
+	filled := slotPattern.ReplaceAllStringFunc(text, func(m string) string {
+		name := strings.Trim(m, "{}")
+		index := 0
+		if last := name[len(name)-1]; last >= '2' && last <= '9' {
+			index = int(last - '1')
+			name = name[:len(name)-1]
+		}
+		values := slots[name]
+		if index >= len(values) {
+			ok = false
+			return m
+		}
+		score++
+		return values[index]
+	})
+	return filled, score, ok
+}
+
+// templateSlots mengumpulkan nilai slot dari tabel: bulan dan tahun terbaru lebih dulu,
+// ruangan dan peralatan yang paling sering muncul lebih dulu
+func templateSlots(table map[string][]string) map[string][]string {
+	slots := make(map[string][]string)
+	if col, ok := dateColumn(table); ok {
+		var days []time.Time
+		seen := make(map[string]bool)
+		for _, v := range table[col] {
+			day, err := time.Parse(dateLayout, strings.TrimSpace(v))
+			if err != nil || seen[v] {
+				continue
+			}
+			seen[v] = true
+			days = append(days, day)
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].After(days[j]) })
+		for _, day := range days {
+			slots["date"] = appendUnique(slots["date"], day.Format(dateLayout))
+			slots["month"] = appendUnique(slots["month"], day.Format("January 2006"))
+			slots["year"] = appendUnique(slots["year"], day.Format("2006"))
+		}
+	}
+	for col, values := range table {
+		switch strings.ToLower(col) {
+		case "room":
+			slots["room"] = byFrequency(values)
+		case "appliance":
+			slots["appliance"] = byFrequency(values)
+		}
+	}
+	return slots
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
+
+// byFrequency mengembalikan nilai unik (huruf kecil) urut dari yang paling sering muncul
+func byFrequency(values []string) []string {
+	counts := make(map[string]int)
+	var unique []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if counts[v] == 0 {
+			unique = append(unique, v)
+		}
+		counts[v]++
+	}
+	sort.SliceStable(unique, func(i, j int) bool { return counts[unique[i]] > counts[unique[j]] })
+	return unique
+}
@@ -0,0 +1,80 @@
+package main_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Question suggestions", func() {
+	It("turns the usage guide examples into templates with slots", func() {
+		texts := []string{}
+		for _, t := range main.ParseTemplates(`1.	Pertanyaan Rata-Rata: Contoh: "How much power was consumed in June 2023?"
+7.	Pertanyaan tentang Perbandingan: "Compare the energy consumption between June 2023 and June 2024."
+8.	Pertanyaan tentang Tahun: "How does the energy consumption in 2023 compare to 2022?"
+10.	Pertanyaan tentang Ruangan: "Compare the energy consumption between the living room and the kitchen."`) {
+			texts = append(texts, t.Text)
+		}
+		Expect(texts).Should(Equal([]string{
+			"How much power was consumed in {month}?",
+			"Compare the energy consumption between {month} and {month2}.",
+			"How does the energy consumption in {year} compare to {year2}?",
+			"Compare the energy consumption between the {room} and the {room2}.",
+		}))
+	})
+
+	It("fills slots from data-series.csv and ranks answerable questions first", func() {
+		data, err := ioutil.ReadFile("data-series.csv")
+		Expect(err).ShouldNot(HaveOccurred())
+		table, err := main.CsvToSlice(string(data))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		suggestions := main.SuggestQuestions(table, 0)
+		Expect(len(suggestions)).Should(BeNumerically(">=", 10))
+		for i, s := range suggestions {
+			Expect(s.Text).ShouldNot(ContainSubstring("{"))
+			if i > 0 {
+				Expect(s.Score).Should(BeNumerically("<=", suggestions[i-1].Score))
+			}
+		}
+		// Tidak ada kolom voltage, jadi pertanyaan tentang voltage ada di akhir
+		Expect(suggestions[len(suggestions)-1].Text).Should(ContainSubstring("voltage"))
+		Expect(main.SuggestQuestions(table, 3)).Should(HaveLen(3))
+	})
+
+	It("serves suggestions for a dataset over HTTP", func() {
+		table := map[string][]string{"Date": {"2023-06-01", "2024-06-01"}, "Energy_Consumption": {"1.2", "0.8"}, "Room": {"Kitchen", "Bedroom"}}
+		registry := main.NewDatasetRegistry(main.Quota{})
+		_, err := registry.Put(main.LocalPrincipal, main.DefaultDatasetID, "home.csv", table)
+		Expect(err).ShouldNot(HaveOccurred())
+		api := httptest.NewServer(main.NewAPIServer(main.NewTableQAServer(&main.Pipeline{Backend: &fakeBackend{}}, registry)).Handler())
+		defer api.Close()
+
+		resp, err := http.Get(api.URL + "/v1/suggestions?limit=2")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		var body struct{ Suggestions []main.Suggestion }
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).Should(Succeed())
+		Expect(body.Suggestions).Should(HaveLen(2))
+		Expect(body.Suggestions[0].Text).Should(Equal("Compare the energy consumption between June 2024 and June 2023."))
+
+		resp, err = http.Get(api.URL + "/v1/suggestions?dataset_id=missing")
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+	})
+
+	It("drops templates whose slots cannot be filled", func() {
+		table := map[string][]string{"Appliance": {"TV"}, "Energy_Consumption": {"1.2"}}
+		for _, s := range main.SuggestQuestions(table, 0) {
+			Expect(s.Text).ShouldNot(ContainSubstring("{"))
+			Expect(s.Text).ShouldNot(ContainSubstring("room"))
+		}
+	})
+})
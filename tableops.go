@@ -0,0 +1,476 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Operasi tabel untuk analitik dan pra-agregasi. Semua fungsi menerima tabel dalam model yang
+// sama dengan CsvToSlice (map kolom ke nilai) dan mengembalikan tabel baru tanpa mengubah input.
+
+// Fungsi agregasi untuk GroupBy dan Pivot
+const (
+	AggSum   = "sum"
+	AggAvg   = "avg"
+	AggMin   = "min"
+	AggMax   = "max"
+	AggCount = "count"
+)
+
+// Aggregation struct satu kolom hasil agregasi, misalnya sum(Energy_Consumption)
+type Aggregation struct {
+	Func   string
+	Column string
+	// As nama kolom hasil, default "<Column>_<Func>" atau "count" untuk count tanpa kolom
+	As string
+}
+
+// Name nama kolom hasil agregasi
+func (a Aggregation) Name() string {
+	switch {
+	case a.As != "":
+		return a.As
+	case a.Column == "":
+		return a.Func
+	}
+	return a.Column + "_" + a.Func
+}
+
+// ParseAggregation mem-parse bentuk "sum(Energy_Consumption)" atau "count()"
+func ParseAggregation(s string) (Aggregation, error) {
+	fn, rest, ok := strings.Cut(strings.TrimSpace(s), "(")
+	if !ok || !strings.HasSuffix(rest, ")") {
+		return Aggregation{}, errors.New(T("table.aggregation_syntax", s))
+	}
+	a := Aggregation{Func: strings.ToLower(strings.TrimSpace(fn)), Column: strings.Trim(strings.TrimSpace(strings.TrimSuffix(rest, ")")), "`")}
+	return a, a.check(nil)
+}
+
+// check memastikan fungsi dikenal dan kolomnya cocok; schema nil hanya memeriksa fungsi
+func (a Aggregation) check(schema Schema) error {
+	switch a.Func {
+	case AggSum, AggAvg, AggMin, AggMax:
+		if a.Column == "" {
+			return errors.New(T("table.needs_column", a.Func))
+		}
+	case AggCount:
+	default:
+		return errors.New(T("table.unknown_aggregation", a.Func))
+	}
+	if schema == nil || a.Column == "" {
+		return nil
+	}
+	kind, ok := schema[a.Column]
+	if !ok {
+		return errors.New(T("table.unknown_column", a.Column))
+	}
+	if (a.Func == AggSum || a.Func == AggAvg) && !kindIs(kind, KindNumber) {
+		return errors.New(T("table.needs_number", a.Func, a.Column, kind))
+	}
+	return nil
+}
+
+// aggregator menampung nilai satu grup untuk satu Aggregation; cell kosong dilewati
+type aggregator struct {
+	agg   Aggregation
+	count int
+	sum   float64
+	best  Value
+}
+
+func (g *aggregator) add(v Value) error {
+	if g.agg.Column != "" && v.Kind == KindNull {
+		return nil
+	}
+	g.count++
+	switch g.agg.Func {
+	case AggSum, AggAvg:
+		g.sum += v.Num
+	case AggMin, AggMax:
+		if g.best.Kind == KindNull {
+			g.best = v
+			return nil
+		}
+		c, err := compareValues(v, g.best)
+		if err != nil {
+			return err
+		}
+		if c < 0 && g.agg.Func == AggMin || c > 0 && g.agg.Func == AggMax {
+			g.best = v
+		}
+	}
+	return nil
+}
+
+func (g *aggregator) result() string {
+	switch g.agg.Func {
+	case AggCount:
+		return formatNumber(float64(g.count))
+	case AggSum:
+		if g.count == 0 {
+			return ""
+		}
+		return formatNumber(g.sum)
+	case AggAvg:
+		if g.count == 0 {
+			return ""
+		}
+		return formatNumber(g.sum / float64(g.count))
+	}
+	return g.best.String()
+}
+
+// GroupBy mengelompokkan baris berdasarkan kolom keys lalu menghitung aggs untuk setiap grup.
+// Urutan grup mengikuti kemunculan pertamanya; tanpa keys semua baris menjadi satu grup.
+func GroupBy(table map[string][]string, keys []string, aggs ...Aggregation) (map[string][]string, error) {
+	schema, readRow := readTable(table)
+	if err := requireColumns(schema, keys...); err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string, len(keys)+len(aggs))
+	for _, a := range aggs {
+		if err := a.check(schema); err != nil {
+			return nil, err
+		}
+		if _, exists := result[a.Name()]; exists || containsString(keys, a.Name()) {
+			return nil, errors.New(T("table.duplicate_column", a.Name()))
+		}
+		result[a.Name()] = nil
+	}
+
+	type group struct {
+		key         []string
+		aggregators []*aggregator
+	}
+	var groups []*group
+	index := make(map[string]*group)
+	for i := 0; i < tableRows(table); i++ {
+		key := make([]string, len(keys))
+		for k, col := range keys {
+			if cells := table[col]; i < len(cells) {
+				key[k] = cells[i]
+			}
+		}
+		id := strings.Join(key, "\x00")
+		g, ok := index[id]
+		if !ok {
+			g = &group{key: key}
+			for _, a := range aggs {
+				g.aggregators = append(g.aggregators, &aggregator{agg: a})
+			}
+			index[id] = g
+			groups = append(groups, g)
+		}
+		row := readRow(i)
+		for _, agg := range g.aggregators {
+			if err := agg.add(row(agg.agg.Column)); err != nil {
+				return nil, fmt.Errorf("%s: %w", T("table.at_row", agg.agg.Name(), i+1), err)
+			}
+		}
+	}
+	// Tabel kosong tanpa keys tetap menghasilkan satu baris, seperti SELECT sum(x) di SQL
+	if len(groups) == 0 && len(keys) == 0 {
+		g := &group{}
+		for _, a := range aggs {
+			g.aggregators = append(g.aggregators, &aggregator{agg: a})
+		}
+		groups = append(groups, g)
+	}
+
+	for k, col := range keys {
+		values := make([]string, len(groups))
+		for i, g := range groups {
+			values[i] = g.key[k]
+		}
+		result[col] = values
+	}
+	for a := range aggs {
+		values := make([]string, len(groups))
+		for i, g := range groups {
+			values[i] = g.aggregators[a].result()
+		}
+		result[aggs[a].Name()] = values
+	}
+	return result, nil
+}
+
+// Pivot membuat tabel silang: satu baris per nilai rowKey dan satu kolom per nilai colKey,
+// misalnya Appliance × Month dengan sum(Energy_Consumption). Kombinasi tanpa data berisi "".
+func Pivot(table map[string][]string, rowKey, colKey string, agg Aggregation) (map[string][]string, error) {
+	agg.As = "value"
+	grouped, err := GroupBy(table, []string{rowKey, colKey}, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	var rowValues []string
+	rowIndex := make(map[string]int)
+	columns := make(map[string]bool)
+	for i, r := range grouped[rowKey] {
+		if _, ok := rowIndex[r]; !ok {
+			rowIndex[r] = len(rowValues)
+			rowValues = append(rowValues, r)
+		}
+		c := grouped[colKey][i]
+		if c == rowKey {
+			return nil, errors.New(T("table.pivot_clash", c))
+		}
+		columns[c] = true
+	}
+
+	result := map[string][]string{rowKey: rowValues}
+	for c := range columns {
+		result[c] = make([]string, len(rowValues))
+	}
+	for i, r := range grouped[rowKey] {
+		result[grouped[colKey][i]][rowIndex[r]] = grouped["value"][i]
+	}
+	return result, nil
+}
+
+// SortBy mengurutkan baris berdasarkan column sesuai tipenya (angka, tanggal, atau teks).
+// Urutannya stabil dan cell kosong selalu di akhir.
+func SortBy(table map[string][]string, column string, desc bool) (map[string][]string, error) {
+	schema, row := readTable(table)
+	if err := requireColumns(schema, column); err != nil {
+		return nil, err
+	}
+	values := make([]Value, tableRows(table))
+	order := make([]int, len(values))
+	for i := range values {
+		values[i] = row(i)(column)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := values[order[i]], values[order[j]]
+		if a.Kind == KindNull || b.Kind == KindNull {
+			return b.Kind == KindNull && a.Kind != KindNull
+		}
+		// Satu kolom selalu satu tipe karena dibaca lewat schema, jadi error tidak mungkin terjadi
+		c, _ := compareValues(a, b)
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	return selectRows(table, order), nil
+}
+
+// Head mengembalikan n baris pertama
+func Head(table map[string][]string, n int) map[string][]string {
+	rows := tableRows(table)
+	if n > rows {
+		n = rows
+	}
+	if n < 0 {
+		n = 0
+	}
+	keep := make([]int, n)
+	for i := range keep {
+		keep[i] = i
+	}
+	return selectRows(table, keep)
+}
+
+// TopN mengembalikan n baris dengan nilai column terbesar
+func TopN(table map[string][]string, column string, n int) (map[string][]string, error) {
+	sorted, err := SortBy(table, column, true)
+	if err != nil {
+		return nil, err
+	}
+	return Head(sorted, n), nil
+}
+
+// Join menambahkan kolom dari tabel lookup ke setiap baris left (left join). Key dicocokkan
+// tanpa membedakan huruf besar-kecil, baris tanpa pasangan mendapat cell kosong.
+// Key di lookup harus unik dan nama kolom lookup tidak boleh sudah ada di left.
+func Join(left, lookup map[string][]string, leftKey, lookupKey string) (map[string][]string, error) {
+	if _, ok := left[leftKey]; !ok {
+		return nil, errors.New(T("table.unknown_column", leftKey))
+	}
+	if _, ok := lookup[lookupKey]; !ok {
+		return nil, errors.New(T("table.unknown_lookup_column", lookupKey))
+	}
+	index := make(map[string]int)
+	for i, key := range lookup[lookupKey] {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, dup := index[key]; dup {
+			return nil, errors.New(T("table.lookup_not_unique", lookup[lookupKey][i]))
+		}
+		index[key] = i
+	}
+
+	result := make(map[string][]string, len(left)+len(lookup)-1)
+	for col, values := range left {
+		result[col] = values
+	}
+	for col, values := range lookup {
+		if col == lookupKey {
+			continue
+		}
+		if _, exists := result[col]; exists {
+			return nil, errors.New(T("table.column_in_both", col))
+		}
+		joined := make([]string, tableRows(left))
+		for i, key := range left[leftKey] {
+			if j, ok := index[strings.ToLower(strings.TrimSpace(key))]; ok && j < len(values) {
+				joined[i] = values[j]
+			}
+		}
+		result[col] = joined
+	}
+	return result, nil
+}
+
+func requireColumns(schema Schema, columns ...string) error {
+	for _, col := range columns {
+		if _, ok := schema[col]; !ok {
+			return errors.New(T("table.unknown_column", col))
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// PreAggregate meringkas tabel yang lebih dari maxRows baris sebelum dikirim ke TAPAS, yang hanya
+// sanggup membaca tabel kecil. Hanya dipakai untuk pertanyaan total, perbandingan, dan tren karena
+// jawabannya tetap sama setelah ukuran aditif (energi, biaya) dijumlahkan: per tanggal (per bulan
+// untuk tren) dan per kolom yang disebut di pertanyaan, lewat namanya atau salah satu nilainya.
+// Mengembalikan false jika tabel tidak perlu diringkas atau ringkasan akan menghilangkan kolom yang
+// ditanyakan, misalnya kolom angka yang tidak bisa dijumlahkan seperti tegangan.
+func PreAggregate(table map[string][]string, query string, maxRows int) (map[string][]string, string, bool) {
+	if maxRows <= 0 || tableRows(table) <= maxRows {
+		return table, "", false
+	}
+	intent := ClassifyIntent(query)
+	if intent != IntentSum && intent != IntentCompare && intent != IntentTrend {
+		return table, "", false
+	}
+
+	schema := InferSchema(table)
+	work := table
+	var keys []string
+	dateCol, hasDate := dateColumn(table)
+	if hasDate {
+		keys = append(keys, dateCol)
+		if _, exists := table["Month"]; intent == IntentTrend && !exists {
+			month := ComputedColumn{Name: "Month", Expr: &callExpr{name: "yearmonth", fn: exprFunctions["yearmonth"], args: []Expr{&columnExpr{name: dateCol}}}}
+			if monthly, err := AddComputedColumn(table, month); err == nil {
+				work, keys = monthly, []string{month.Name}
+			}
+		}
+	}
+	q := strings.ToLower(query)
+	var aggs []Aggregation
+	var measures []string
+	for _, col := range sortedColumns(table) {
+		if hasDate && col == dateCol {
+			continue
+		}
+		mentioned := mentionsColumn(q, col, table[col])
+		switch {
+		case schema[col] == KindNumber && additiveMeasure(col):
+			aggs = append(aggs, Aggregation{Func: AggSum, Column: col, As: col})
+			measures = append(measures, AggSum+"("+col+")")
+		case schema[col] == KindNumber:
+			// Angka yang tidak aditif tidak bisa dijumlahkan; jika ditanyakan, tabel dikirim apa adanya
+			if mentioned {
+				return table, "", false
+			}
+		case mentioned:
+			keys = append(keys, col)
+		}
+	}
+	if len(aggs) == 0 {
+		return table, "", false
+	}
+	summary, err := GroupBy(work, keys, aggs...)
+	if err != nil || tableRows(summary) >= tableRows(table) {
+		return table, "", false
+	}
+	return summary, strings.Join(measures, ", ") + " by " + strings.Join(keys, ", "), true
+}
+
+// nonAdditiveHints bagian nama kolom angka yang jumlahnya tidak bermakna (rata-rata, tarif, sesaat)
+var nonAdditiveHints = []string{"voltage", "volt", "current", "power", "temperature", "temp", "humidity", "rate", "price",
+	"tariff", "percent", "pct", "ratio", "factor", "average", "avg", "mean", "id", "year", "month", "day", "hour", "minute"}
+
+// additiveHints bagian nama kolom angka yang boleh dijumlahkan per kelompok
+var additiveHints = []string{"energy", "consumption", "kwh", "wh", "usage", "used", "cost", "bill", "amount",
+	"spent", "count", "total", "quantity", "duration"}
+
+// additiveMeasure true jika kolom angka col boleh dijumlahkan. Kolom yang tidak dikenal dianggap tidak
+// aditif agar ringkasan tidak pernah berisi jumlah yang tidak bermakna.
+func additiveMeasure(col string) bool {
+	words := strings.FieldsFunc(strings.ToLower(col), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, w := range words {
+		if containsString(nonAdditiveHints, w) {
+			return false
+		}
+	}
+	for _, w := range words {
+		if containsString(additiveHints, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnSynonyms kata lain di pertanyaan yang berarti pengelompokan per kolom itu
+var columnSynonyms = map[string][]string{
+	"time": {"hour", "hours", "hourly", "o'clock"},
+}
+
+// mentionsColumn true jika pertanyaan q (huruf kecil) menyebut nama kolom, sinonimnya, atau salah satu
+// nilainya sebagai kata utuh, berapa pun panjangnya ("TV" cocok dengan "the tv?" tetapi tidak dengan "tvs")
+func mentionsColumn(q, col string, values []string) bool {
+	name := strings.ToLower(strings.Replace(col, "_", " ", -1))
+	if containsWord(q, name) {
+		return true
+	}
+	for _, w := range columnSynonyms[name] {
+		if containsWord(q, w) {
+			return true
+		}
+	}
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" && containsWord(q, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord true jika w muncul di s dan tidak diapit huruf atau angka
+func containsWord(s, w string) bool {
+	for start := 0; start <= len(s)-len(w); {
+		i := strings.Index(s[start:], w)
+		if i < 0 {
+			return false
+		}
+		i += start
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[i+len(w):])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		start = i + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
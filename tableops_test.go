@@ -0,0 +1,157 @@
+package main_test
+
+import (
+	"context"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Table operations", func() {
+	table := map[string][]string{
+		"Date":               {"2023-05-31", "2023-05-31", "2023-06-01", "2023-06-01", "2023-06-02"},
+		"Appliance":          {"TV", "Fridge", "TV", "Fridge", "Heater"},
+		"Energy_Consumption": {"0.5", "1.2", "0.7", "", "10"},
+		"Room":               {"Living Room", "Kitchen", "Living Room", "Kitchen", "Bedroom"},
+	}
+
+	It("groups rows and skips empty cells", func() {
+		out, err := main.GroupBy(table, []string{"Appliance"},
+			main.Aggregation{Func: main.AggSum, Column: "Energy_Consumption"},
+			main.Aggregation{Func: main.AggAvg, Column: "Energy_Consumption"},
+			main.Aggregation{Func: main.AggMax, Column: "Date"},
+			main.Aggregation{Func: main.AggCount},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(out).Should(Equal(map[string][]string{
+			"Appliance":              {"TV", "Fridge", "Heater"},
+			"Energy_Consumption_sum": {"1.2", "1.2", "10"},
+			"Energy_Consumption_avg": {"0.6", "1.2", "10"},
+			"Date_max":               {"2023-06-01", "2023-06-01", "2023-06-02"},
+			"count":                  {"2", "2", "1"},
+		}))
+		Expect(table["Appliance"]).Should(HaveLen(5))
+	})
+
+	It("pivots appliances by month using a computed column", func() {
+		c, err := main.ParseComputedColumn("Month = yearmonth(Date)")
+		Expect(err).ShouldNot(HaveOccurred())
+		monthly, err := main.AddComputedColumn(table, c)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		out, err := main.Pivot(monthly, "Appliance", "Month", main.Aggregation{Func: main.AggSum, Column: "Energy_Consumption"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(out).Should(Equal(map[string][]string{
+			"Appliance": {"TV", "Fridge", "Heater"},
+			"2023-05":   {"0.5", "1.2", ""},
+			"2023-06":   {"0.7", "", "10"},
+		}))
+	})
+
+	It("sorts by type, keeps empty cells last and takes the top N", func() {
+		sorted, err := main.SortBy(table, "Energy_Consumption", false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sorted["Energy_Consumption"]).Should(Equal([]string{"0.5", "0.7", "1.2", "10", ""}))
+
+		top, err := main.TopN(table, "Energy_Consumption", 2)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(top["Appliance"]).Should(Equal([]string{"Heater", "Fridge"}))
+	})
+
+	It("left-joins a lookup table", func() {
+		lookup := map[string][]string{"Name": {"tv", "Fridge"}, "Watt": {"100", "150"}}
+		out, err := main.Join(table, lookup, "Appliance", "Name")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(out["Watt"]).Should(Equal([]string{"100", "150", "100", "150", ""}))
+		Expect(out).ShouldNot(HaveKey("Name"))
+
+		_, err = main.Join(table, map[string][]string{"Name": {"TV", "tv"}, "Watt": {"1", "2"}}, "Appliance", "Name")
+		Expect(err).Should(MatchError(ContainSubstring("not unique")))
+		_, err = main.Join(table, map[string][]string{"Appliance": {"TV"}, "Room": {"x"}}, "Appliance", "Appliance")
+		Expect(err).Should(MatchError(ContainSubstring("exists in both tables")))
+	})
+
+	DescribeTable("rejects invalid operations",
+		func(agg, message string) {
+			a, err := main.ParseAggregation(agg)
+			if err == nil {
+				_, err = main.GroupBy(table, []string{"Room"}, a)
+			}
+			Expect(err).Should(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown function", "median(Energy_Consumption)", `unknown aggregation "median"`),
+		Entry("sum of text", "sum(Room)", "needs a number column"),
+		Entry("unknown column", "max(Power)", `unknown column "Power"`),
+		Entry("malformed", "sum Energy_Consumption", "must look like"),
+	)
+
+	It("pre-aggregates large tables before asking total questions", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "SUM > 1.2", Coordinates: [][]int{{0, 1}}, Cells: []string{"1.2"}, Aggregator: "SUM"}}
+		pipeline := &main.Pipeline{Backend: backend, PreAggregate: 3}
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy used?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[0].Table).Should(Equal(map[string][]string{
+			"Date":               {"2023-05-31", "2023-06-01", "2023-06-02"},
+			"Energy_Consumption": {"1.7", "0.7", "10"},
+		}))
+		Expect(result.PreAggregation).Should(Equal("sum(Energy_Consumption) by Date"))
+		Expect(result.Explanation.Cells[0].SourceRow).Should(Equal(-1))
+
+		// Ruangan disebut di pertanyaan sehingga ikut menjadi key; ringkasannya tidak lebih kecil, jadi tidak dipakai
+		result, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy used in the kitchen?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[1].Table).Should(Equal(table))
+		Expect(result.PreAggregation).Should(BeEmpty())
+
+		// Pertanyaan selain total, perbandingan, dan tren tetap memakai baris asli
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[2].Table).Should(Equal(table))
+
+		result, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "How does energy change over time?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[3].Table).Should(Equal(map[string][]string{
+			"Month":              {"2023-05", "2023-06"},
+			"Energy_Consumption": {"1.7", "10.7"},
+		}))
+		Expect(result.PreAggregation).Should(Equal("sum(Energy_Consumption) by Month"))
+	})
+
+	It("keeps short values, non-additive columns and asked-for groupings when pre-aggregating", func() {
+		hourly := map[string][]string{
+			"Date":               {"2022-01-01", "2022-01-01", "2022-01-01", "2022-01-01", "2022-01-02", "2022-01-02"},
+			"Time":               {"00:00", "01:00", "00:00", "01:00", "00:00", "01:00"},
+			"Appliance":          {"TV", "TV", "Refrigerator", "Refrigerator", "TV", "Refrigerator"},
+			"Energy_Consumption": {"0.5", "0.6", "1.2", "1.2", "0.4", "1.1"},
+			"Voltage":            {"220", "221", "219", "220", "222", "218"},
+		}
+
+		// "TV" hanya dua huruf tetapi tetap menjadi key, sehingga totalnya bukan total semua alat
+		summary, desc, ok := main.PreAggregate(hourly, "What is the total energy used by the TV?", 1)
+		Expect(ok).Should(BeTrue())
+		Expect(desc).Should(Equal("sum(Energy_Consumption) by Date, Appliance"))
+		Expect(summary).Should(Equal(map[string][]string{
+			"Date":               {"2022-01-01", "2022-01-01", "2022-01-02", "2022-01-02"},
+			"Appliance":          {"TV", "Refrigerator", "TV", "Refrigerator"},
+			"Energy_Consumption": {"1.1", "2.4", "0.4", "1.1"},
+		}))
+
+		// Nilai dicocokkan sebagai kata utuh
+		_, desc, _ = main.PreAggregate(hourly, "What is the total energy of all TVs?", 1)
+		Expect(desc).Should(Equal("sum(Energy_Consumption) by Date"))
+
+		// Jam yang ditanyakan ikut menjadi key, sedangkan tegangan tidak bisa dijumlahkan sama sekali
+		_, desc, _ = main.PreAggregate(hourly, "What is the total energy per hour?", 1)
+		Expect(desc).Should(Equal("sum(Energy_Consumption) by Date, Time"))
+		_, _, ok = main.PreAggregate(hourly, "What is the total voltage?", 1)
+		Expect(ok).Should(BeFalse())
+
+		backend := &fakeBackend{response: main.Response{Answer: "1", Coordinates: [][]int{{0, 2}}, Cells: []string{"1.1"}, Aggregator: "NONE"}}
+		pipeline := &main.Pipeline{Backend: backend, MaxTokens: 60}
+		_, err := pipeline.Ask(context.Background(), main.Question{Table: hourly, Query: "What is the total energy used by the TV?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(backend.asked[0].Table["Appliance"]).Should(ContainElement("TV"))
+	})
+})
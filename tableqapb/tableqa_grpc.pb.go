@@ -0,0 +1,304 @@
+// Kontrak service gRPC untuk tanya-jawab tabel energi.
+//
+// Server memakai codec protobuf standar gRPC, jadi client bahasa lain cukup
+// men-generate stub dari file ini. Stub Go ada di package tableqapb, dibuat
+// ulang dengan `go generate` (lihat grpc.go).
+//
+// Jika autentikasi aktif, kirim metadata "authorization: Bearer <jwt|api-key>"
+// atau "x-api-key: <api-key>". Dataset milik tenant lain selalu NOT_FOUND.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: tableqa/v1/tableqa.proto
+
+package tableqapb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TableQA_Ask_FullMethodName           = "/tableqa.v1.TableQA/Ask"
+	TableQA_StreamAsk_FullMethodName     = "/tableqa.v1.TableQA/StreamAsk"
+	TableQA_UploadDataset_FullMethodName = "/tableqa.v1.TableQA/UploadDataset"
+	TableQA_ListDatasets_FullMethodName  = "/tableqa.v1.TableQA/ListDatasets"
+	TableQA_ShareDataset_FullMethodName  = "/tableqa.v1.TableQA/ShareDataset"
+)
+
+// TableQAClient is the client API for TableQA service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TableQAClient interface {
+	// Ask mengirim satu pertanyaan dan menunggu jawaban.
+	Ask(ctx context.Context, in *AskRequest, opts ...grpc.CallOption) (*AskResponse, error)
+	// StreamAsk sama seperti Ask, tetapi mengirim event progress selama model loading.
+	StreamAsk(ctx context.Context, in *AskRequest, opts ...grpc.CallOption) (TableQA_StreamAskClient, error)
+	// UploadDataset menyimpan tabel CSV agar bisa dipakai oleh Ask.
+	UploadDataset(ctx context.Context, in *UploadDatasetRequest, opts ...grpc.CallOption) (*DatasetInfo, error)
+	// ListDatasets menampilkan semua dataset yang bisa diakses pemanggil.
+	ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error)
+	// ShareDataset memberi role owner/viewer ke user lain, hanya untuk owner.
+	ShareDataset(ctx context.Context, in *ShareDatasetRequest, opts ...grpc.CallOption) (*ShareDatasetResponse, error)
+}
+
+type tableQAClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTableQAClient(cc grpc.ClientConnInterface) TableQAClient {
+	return &tableQAClient{cc}
+}
+
+func (c *tableQAClient) Ask(ctx context.Context, in *AskRequest, opts ...grpc.CallOption) (*AskResponse, error) {
+	out := new(AskResponse)
+	err := c.cc.Invoke(ctx, TableQA_Ask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableQAClient) StreamAsk(ctx context.Context, in *AskRequest, opts ...grpc.CallOption) (TableQA_StreamAskClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TableQA_ServiceDesc.Streams[0], TableQA_StreamAsk_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tableQAStreamAskClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TableQA_StreamAskClient interface {
+	Recv() (*AskEvent, error)
+	grpc.ClientStream
+}
+
+type tableQAStreamAskClient struct {
+	grpc.ClientStream
+}
+
+func (x *tableQAStreamAskClient) Recv() (*AskEvent, error) {
+	m := new(AskEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tableQAClient) UploadDataset(ctx context.Context, in *UploadDatasetRequest, opts ...grpc.CallOption) (*DatasetInfo, error) {
+	out := new(DatasetInfo)
+	err := c.cc.Invoke(ctx, TableQA_UploadDataset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableQAClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
+	out := new(ListDatasetsResponse)
+	err := c.cc.Invoke(ctx, TableQA_ListDatasets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableQAClient) ShareDataset(ctx context.Context, in *ShareDatasetRequest, opts ...grpc.CallOption) (*ShareDatasetResponse, error) {
+	out := new(ShareDatasetResponse)
+	err := c.cc.Invoke(ctx, TableQA_ShareDataset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TableQAServer is the server API for TableQA service.
+// All implementations must embed UnimplementedTableQAServer
+// for forward compatibility
+type TableQAServer interface {
+	// Ask mengirim satu pertanyaan dan menunggu jawaban.
+	Ask(context.Context, *AskRequest) (*AskResponse, error)
+	// StreamAsk sama seperti Ask, tetapi mengirim event progress selama model loading.
+	StreamAsk(*AskRequest, TableQA_StreamAskServer) error
+	// UploadDataset menyimpan tabel CSV agar bisa dipakai oleh Ask.
+	UploadDataset(context.Context, *UploadDatasetRequest) (*DatasetInfo, error)
+	// ListDatasets menampilkan semua dataset yang bisa diakses pemanggil.
+	ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error)
+	// ShareDataset memberi role owner/viewer ke user lain, hanya untuk owner.
+	ShareDataset(context.Context, *ShareDatasetRequest) (*ShareDatasetResponse, error)
+	mustEmbedUnimplementedTableQAServer()
+}
+
+// UnimplementedTableQAServer must be embedded to have forward compatible implementations.
+type UnimplementedTableQAServer struct {
+}
+
+func (UnimplementedTableQAServer) Ask(context.Context, *AskRequest) (*AskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ask not implemented")
+}
+func (UnimplementedTableQAServer) StreamAsk(*AskRequest, TableQA_StreamAskServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAsk not implemented")
+}
+func (UnimplementedTableQAServer) UploadDataset(context.Context, *UploadDatasetRequest) (*DatasetInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadDataset not implemented")
+}
+func (UnimplementedTableQAServer) ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatasets not implemented")
+}
+func (UnimplementedTableQAServer) ShareDataset(context.Context, *ShareDatasetRequest) (*ShareDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareDataset not implemented")
+}
+func (UnimplementedTableQAServer) mustEmbedUnimplementedTableQAServer() {}
+
+// UnsafeTableQAServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TableQAServer will
+// result in compilation errors.
+type UnsafeTableQAServer interface {
+	mustEmbedUnimplementedTableQAServer()
+}
+
+func RegisterTableQAServer(s grpc.ServiceRegistrar, srv TableQAServer) {
+	s.RegisterService(&TableQA_ServiceDesc, srv)
+}
+
+func _TableQA_Ask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableQAServer).Ask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableQA_Ask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableQAServer).Ask(ctx, req.(*AskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableQA_StreamAsk_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AskRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TableQAServer).StreamAsk(m, &tableQAStreamAskServer{stream})
+}
+
+type TableQA_StreamAskServer interface {
+	Send(*AskEvent) error
+	grpc.ServerStream
+}
+
+type tableQAStreamAskServer struct {
+	grpc.ServerStream
+}
+
+func (x *tableQAStreamAskServer) Send(m *AskEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TableQA_UploadDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableQAServer).UploadDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableQA_UploadDataset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableQAServer).UploadDataset(ctx, req.(*UploadDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableQA_ListDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatasetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableQAServer).ListDatasets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableQA_ListDatasets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableQAServer).ListDatasets(ctx, req.(*ListDatasetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableQA_ShareDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableQAServer).ShareDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TableQA_ShareDataset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableQAServer).ShareDataset(ctx, req.(*ShareDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TableQA_ServiceDesc is the grpc.ServiceDesc for TableQA service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TableQA_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tableqa.v1.TableQA",
+	HandlerType: (*TableQAServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ask",
+			Handler:    _TableQA_Ask_Handler,
+		},
+		{
+			MethodName: "UploadDataset",
+			Handler:    _TableQA_UploadDataset_Handler,
+		},
+		{
+			MethodName: "ListDatasets",
+			Handler:    _TableQA_ListDatasets_Handler,
+		},
+		{
+			MethodName: "ShareDataset",
+			Handler:    _TableQA_ShareDataset_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAsk",
+			Handler:       _TableQA_StreamAsk_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tableqa/v1/tableqa.proto",
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// tapasTokenTypes jumlah token_type_ids per token TAPAS: segment, column, row, prev_labels,
+// column_ranks, inv_column_ranks, numeric_relations
+const tapasTokenTypes = 7
+
+// tapasCellThreshold batas probabilitas cell ikut dipilih, sama dengan cell_classification_threshold TAPAS
+const tapasCellThreshold = 0.5
+
+// TapasAggregators label agregator model TAPAS WTQ sesuai urutan logits agregasinya
+var TapasAggregators = []string{"NONE", "SUM", "AVERAGE", "COUNT"}
+
+// ErrLocalModel dikembalikan jika model lokal gagal dijalankan atau output-nya tidak sesuai
+var ErrLocalModel = messageError("error.local_model")
+
+// ErrONNXUnavailable dikembalikan oleh NewONNXBackend jika binary dibangun tanpa build tag onnx
+var ErrONNXUnavailable = messageError("error.onnx_unavailable")
+
+// TapasEncoding struct input model TAPAS hasil EncodeTapas. TokenTypeIDs berisi tapasTokenTypes
+// nilai untuk setiap token secara berurutan, sesuai tensor [1, len(InputIDs), 7].
+type TapasEncoding struct {
+	InputIDs      []int64
+	AttentionMask []int64
+	TokenTypeIDs  []int64
+	// Columns urutan kolom tabel, sama dengan indeks kolom di Response.Coordinates
+	Columns []string
+	// Rows jumlah baris yang ikut dikodekan; baris setelahnya dibuang agar muat
+	Rows int
+	// cells koordinat [row, col] untuk setiap token, {-1, -1} untuk token pertanyaan dan header
+	cells [][2]int
+}
+
+// EncodeTapas mengubah inputs menjadi input model TAPAS: [CLS] pertanyaan [SEP], nama kolom,
+// lalu cell baris per baris. Baris yang membuat panjang melebihi maxLen dibuang utuh seperti
+// drop_rows_to_fit di TapasTokenizer. maxLen 0 berarti TapasMaxTokens.
+func EncodeTapas(tokenizer *WordPieceTokenizer, inputs Inputs, maxLen int) TapasEncoding {
+	if maxLen <= 0 {
+		maxLen = TapasMaxTokens
+	}
+	enc := TapasEncoding{Columns: sortedColumns(inputs.Table)}
+	add := func(tokens []string, types [tapasTokenTypes]int64, cell [2]int) {
+		for _, id := range tokenizer.IDs(tokens) {
+			enc.InputIDs = append(enc.InputIDs, id)
+			enc.AttentionMask = append(enc.AttentionMask, 1)
+			enc.TokenTypeIDs = append(enc.TokenTypeIDs, types[:]...)
+			enc.cells = append(enc.cells, cell)
+		}
+	}
+	noCell := [2]int{-1, -1}
+
+	query := append(append([]string{"[CLS]"}, tokenizer.Tokenize(inputs.Query)...), "[SEP]")
+	add(query, [tapasTokenTypes]int64{}, noCell)
+	for col, name := range enc.Columns {
+		add(tokenizer.Tokenize(name), [tapasTokenTypes]int64{1, int64(col + 1)}, noCell)
+	}
+
+	ranks := columnRanks(inputs.Table, enc.Columns)
+	questionNumbers := queryNumbers(inputs.Query)
+	prev := make(map[[2]int]bool)
+	for _, c := range inputs.PrevCoordinates {
+		if len(c) == 2 {
+			prev[[2]int{c[0], c[1]}] = true
+		}
+	}
+	for row := 0; row < tableRows(inputs.Table); row++ {
+		var rowTokens [][]string
+		length := 0
+		for _, col := range enc.Columns {
+			var cell string
+			if cells := inputs.Table[col]; row < len(cells) {
+				cell = cells[row]
+			}
+			tokens := tokenizer.Tokenize(cell)
+			if len(tokens) == 0 {
+				tokens = []string{"[EMPTY]"}
+			}
+			rowTokens = append(rowTokens, tokens)
+			length += len(tokens)
+		}
+		if len(enc.InputIDs)+length > maxLen {
+			break
+		}
+		for col, tokens := range rowTokens {
+			types := [tapasTokenTypes]int64{1, int64(col + 1), int64(row + 1)}
+			if prev[[2]int{row, col}] {
+				types[3] = 1
+			}
+			types[4], types[5] = ranks[col].rank(row)
+			types[6] = numericRelations(questionNumbers, inputs.Table[enc.Columns[col]], row)
+			add(tokens, types, [2]int{row, col})
+		}
+		enc.Rows++
+	}
+	return enc
+}
+
+// columnRank struct peringkat cell dalam satu kolom angka atau tanggal, 0 untuk cell kosong
+type columnRank struct {
+	ranks []int64
+	max   int64
+}
+
+func (r columnRank) rank(row int) (int64, int64) {
+	if row >= len(r.ranks) || r.ranks[row] == 0 {
+		return 0, 0
+	}
+	return r.ranks[row], r.max - r.ranks[row] + 1
+}
+
+// columnRanks memberi peringkat rapat (1, 2, 2, 3) untuk kolom yang semua isinya angka atau
+// semua tanggal; kolom lain tidak punya peringkat seperti di TAPAS
+func columnRanks(table map[string][]string, columns []string) []columnRank {
+	schema := InferSchema(table)
+	result := make([]columnRank, len(columns))
+	for i, col := range columns {
+		if kind := schema[col]; kind != KindNumber && kind != KindDate {
+			continue
+		}
+		values := make([]Value, len(table[col]))
+		var distinct []Value
+		for row, cell := range table[col] {
+			values[row] = cellValue(cell)
+			if values[row].Kind != KindNull {
+				distinct = append(distinct, values[row])
+			}
+		}
+		sort.SliceStable(distinct, func(a, b int) bool {
+			c, _ := compareValues(distinct[a], distinct[b])
+			return c < 0
+		})
+		ranks := make(map[Value]int64)
+		for _, v := range distinct {
+			if _, ok := ranks[v]; !ok {
+				ranks[v] = int64(len(ranks) + 1)
+			}
+		}
+		r := columnRank{ranks: make([]int64, len(values)), max: int64(len(ranks))}
+		for row, v := range values {
+			r.ranks[row] = ranks[v]
+		}
+		result[i] = r
+	}
+	return result
+}
+
+// queryNumbers mengambil angka yang disebut di pertanyaan, misalnya 2 dari "more than 2 kWh"
+func queryNumbers(query string) []float64 {
+	var numbers []float64
+	for _, word := range strings.Fields(query) {
+		if v := cellValue(strings.Trim(word, "?!,;:")); v.Kind == KindNumber {
+			numbers = append(numbers, v.Num)
+		}
+	}
+	return numbers
+}
+
+// numericRelations bitmask relasi angka di pertanyaan dengan cell: 1 sama, 2 pertanyaan lebih
+// kecil, 4 pertanyaan lebih besar, sesuai fitur numeric_relations TAPAS
+func numericRelations(numbers []float64, cells []string, row int) int64 {
+	if len(numbers) == 0 || row >= len(cells) {
+		return 0
+	}
+	v := cellValue(cells[row])
+	if v.Kind != KindNumber {
+		return 0
+	}
+	var relations int64
+	for _, n := range numbers {
+		switch {
+		case n == v.Num:
+			relations |= 1
+		case n < v.Num:
+			relations |= 2
+		default:
+			relations |= 4
+		}
+	}
+	return relations
+}
+
+// DecodeTapas mengubah output model menjadi Response seperti Huggingface Inference API.
+// Probabilitas cell adalah rata-rata sigmoid logits token-tokennya; cell di atas 0.5 dipilih.
+// Agregator diambil dari softmax aggregation logits, NONE jika model tidak punya kepala agregasi.
+func DecodeTapas(enc TapasEncoding, table map[string][]string, logits, aggregation []float32) Response {
+	type cellScore struct {
+		sum   float64
+		count int
+	}
+	scores := make(map[[2]int]*cellScore)
+	var order [][2]int
+	for i, cell := range enc.cells {
+		if cell[0] < 0 || i >= len(logits) {
+			continue
+		}
+		s, ok := scores[cell]
+		if !ok {
+			s = &cellScore{}
+			scores[cell] = s
+			order = append(order, cell)
+		}
+		s.sum += 1 / (1 + math.Exp(-float64(logits[i])))
+		s.count++
+	}
+
+	resp := Response{Aggregator: "NONE"}
+	for _, cell := range order {
+		p := scores[cell].sum / float64(scores[cell].count)
+		if p <= tapasCellThreshold {
+			continue
+		}
+		resp.Coordinates = append(resp.Coordinates, []int{cell[0], cell[1]})
+		resp.Cells = append(resp.Cells, table[enc.Columns[cell[1]]][cell[0]])
+		resp.CellProbabilities = append(resp.CellProbabilities, p)
+	}
+
+	if len(aggregation) > 0 {
+		resp.AggregatorProbabilities = make(map[string]float64, len(aggregation))
+		best := 0
+		for i, logit := range aggregation {
+			if logit > aggregation[best] {
+				best = i
+			}
+		}
+		// Logit terbesar dikurangkan dulu agar math.Exp tidak overflow menjadi Inf (hasil NaN)
+		top, total := float64(aggregation[best]), 0.0
+		for _, logit := range aggregation {
+			total += math.Exp(float64(logit) - top)
+		}
+		for i, logit := range aggregation {
+			if i < len(TapasAggregators) {
+				resp.AggregatorProbabilities[TapasAggregators[i]] = math.Exp(float64(logit)-top) / total
+			}
+		}
+		if best < len(TapasAggregators) {
+			resp.Aggregator = TapasAggregators[best]
+		}
+	}
+
+	resp.Answer = tapasAnswer(resp.Aggregator, resp.Cells)
+	return resp
+}
+
+// tapasAnswer menyusun Answer seperti Huggingface: "SUM > 1.2, 0.5", atau cell saja untuk NONE
+func tapasAnswer(aggregator string, cells []string) string {
+	answer := strings.Join(cells, ", ")
+	if aggregator != "" && aggregator != "NONE" {
+		answer = aggregator + " > " + answer
+	}
+	return answer
+}
+
+// TapasSession interface untuk menjalankan model TAPAS yang sudah diekspor, misalnya lewat
+// ONNX Runtime. Run mengembalikan satu logit per token dan aggregation logits (boleh kosong).
+type TapasSession interface {
+	Run(enc TapasEncoding) (logits, aggregation []float32, err error)
+	Close() error
+}
+
+// LocalBackend struct untuk menjawab pertanyaan dengan model TAPAS di mesin sendiri, tanpa
+// mengirim tabel ke Huggingface. Tokenisasi dan decoding dilakukan di Go; Session hanya
+// menjalankan modelnya.
+type LocalBackend struct {
+	Session TapasSession
+	// Model path atau nama model untuk Name()
+	Model string
+	// MaxTokens panjang input maksimal, 0 berarti TapasMaxTokens
+	MaxTokens int
+}
+
+// Name mengembalikan nama backend
+func (b *LocalBackend) Name() string {
+	return "local:" + b.Model
+}
+
+// Ask mengodekan payload, menjalankan model, lalu men-decode koordinat dan agregatornya
+func (b *LocalBackend) Ask(ctx context.Context, payload Inputs) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+	enc := EncodeTapas(TapasTokenizer(), payload, b.MaxTokens)
+	logits, aggregation, err := b.Session.Run(enc)
+	if err != nil {
+		return Response{}, fmt.Errorf("%w: %v", ErrLocalModel, err)
+	}
+	if len(logits) != len(enc.InputIDs) {
+		return Response{}, fmt.Errorf("%w: %s", ErrLocalModel, T("model.logit_count", len(logits), len(enc.InputIDs)))
+	}
+	return DecodeTapas(enc, payload.Table, logits, aggregation), nil
+}
+
+// Close melepaskan session model
+func (b *LocalBackend) Close() error {
+	return b.Session.Close()
+}
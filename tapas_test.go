@@ -0,0 +1,136 @@
+package main_test
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSession TapasSession yang memilih cell berdasarkan token_type_ids kolom dan baris
+type fakeSession struct {
+	cells       [][2]int
+	aggregation []float32
+	err         error
+	short       bool
+	closed      bool
+}
+
+func (s *fakeSession) Run(enc main.TapasEncoding) ([]float32, []float32, error) {
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	logits := make([]float32, len(enc.InputIDs))
+	for i := range logits {
+		logits[i] = -10
+		row, col := enc.TokenTypeIDs[i*7+2], enc.TokenTypeIDs[i*7+1]
+		for _, c := range s.cells {
+			if row == int64(c[0]+1) && col == int64(c[1]+1) {
+				logits[i] = 10
+			}
+		}
+	}
+	if s.short {
+		logits = logits[1:]
+	}
+	return logits, s.aggregation, nil
+}
+
+func (s *fakeSession) Close() error {
+	s.closed = true
+	return nil
+}
+
+var _ = Describe("Local TAPAS inference", func() {
+	table := map[string][]string{
+		"Appliance":          {"TV", "Refrigerator", "Heater"},
+		"Energy_Consumption": {"0.5", "1.2", "0.5"},
+		"Room":               {"Living Room", "", "Bedroom"},
+	}
+
+	It("encodes the question, header and cells with TAPAS token types", func() {
+		tokenizer := main.TapasTokenizer()
+		enc := main.EncodeTapas(tokenizer, main.Inputs{Table: table, Query: "Which used more than 1 kWh?", PrevCoordinates: [][]int{{1, 0}}}, 0)
+		Expect(enc.Rows).Should(Equal(3))
+		Expect(enc.InputIDs[0]).Should(Equal(tokenizer.IDs([]string{"[CLS]"})[0]))
+		Expect(enc.InputIDs).Should(HaveLen(main.EstimateTokens(main.Inputs{Table: table, Query: "Which used more than 1 kWh?"}, 0).Total))
+		Expect(enc.TokenTypeIDs).Should(HaveLen(len(enc.InputIDs) * 7))
+		Expect(enc.AttentionMask).Should(HaveEach(int64(1)))
+
+		types := func(row, col int) []int64 {
+			for i := range enc.InputIDs {
+				if t := enc.TokenTypeIDs[i*7 : i*7+7]; t[2] == int64(row+1) && t[1] == int64(col+1) {
+					return t
+				}
+			}
+			return nil
+		}
+		// Refrigerator: baris 2, kolom 1, jawaban sebelumnya
+		Expect(types(1, 0)).Should(Equal([]int64{1, 1, 2, 1, 0, 0, 0}))
+		// 1.2 peringkat 2 dari 2 nilai berbeda dan lebih besar dari angka 1 di pertanyaan
+		Expect(types(1, 1)).Should(Equal([]int64{1, 2, 2, 0, 2, 1, 2}))
+		Expect(types(2, 1)).Should(Equal([]int64{1, 2, 3, 0, 1, 2, 4}))
+		Expect(types(1, 2)).Should(Equal([]int64{1, 3, 2, 0, 0, 0, 0}))
+		Expect(tokenizer.IDs([]string{"[EMPTY]", "not-a-token"})).Should(Equal(tokenizer.IDs([]string{"[EMPTY]", "[UNK]"})))
+	})
+
+	It("drops rows that do not fit", func() {
+		inputs := main.Inputs{Table: table, Query: "Which appliance?"}
+		enc := main.EncodeTapas(main.TapasTokenizer(), inputs, 20)
+		Expect(enc.Rows).Should(Equal(1))
+		Expect(enc.Rows).Should(Equal(main.EstimateTokens(inputs, 20).RowsFit))
+		Expect(len(enc.InputIDs)).Should(BeNumerically("<=", 20))
+	})
+
+	It("decodes selected cells and the aggregator", func() {
+		enc := main.EncodeTapas(main.TapasTokenizer(), main.Inputs{Table: table, Query: "What is the total energy?"}, 0)
+		session := &fakeSession{cells: [][2]int{{0, 1}, {2, 1}}, aggregation: []float32{0, 3, 1, 0}}
+		logits, aggregation, err := session.Run(enc)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp := main.DecodeTapas(enc, table, logits, aggregation)
+		Expect(resp.Answer).Should(Equal("SUM > 0.5, 0.5"))
+		Expect(resp.Coordinates).Should(Equal([][]int{{0, 1}, {2, 1}}))
+		Expect(resp.Cells).Should(Equal([]string{"0.5", "0.5"}))
+		Expect(resp.CellProbabilities[0]).Should(BeNumerically(">", 0.99))
+		Expect(resp.AggregatorProbabilities["SUM"]).Should(BeNumerically(">", 0.5))
+
+		resp = main.DecodeTapas(enc, table, logits, nil)
+		Expect(resp.Aggregator).Should(Equal("NONE"))
+		Expect(resp.Answer).Should(Equal("0.5, 0.5"))
+	})
+
+	It("keeps aggregator probabilities finite for large logits", func() {
+		enc := main.EncodeTapas(main.TapasTokenizer(), main.Inputs{Table: table, Query: "What is the total energy?"}, 0)
+		logits, _, err := (&fakeSession{cells: [][2]int{{0, 1}}}).Run(enc)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp := main.DecodeTapas(enc, table, logits, []float32{1000, 990, 0, -1000})
+		Expect(resp.Aggregator).Should(Equal("NONE"))
+		Expect(resp.AggregatorProbabilities["NONE"]).Should(BeNumerically("~", 1, 1e-4))
+		Expect(resp.AggregatorProbabilities["SUM"]).Should(BeNumerically(">", 0))
+		confidence, ok := resp.Confidence()
+		Expect(ok).Should(BeTrue())
+		Expect(math.IsNaN(confidence)).Should(BeFalse())
+	})
+
+	It("answers through the pipeline without calling Huggingface", func() {
+		session := &fakeSession{cells: [][2]int{{1, 0}}, aggregation: []float32{5, 0, 0, 0}}
+		backend := &main.LocalBackend{Session: session, Model: "tapas-wtq.onnx"}
+		result, err := (&main.Pipeline{Backend: backend}).Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Answer).Should(Equal("Refrigerator"))
+		Expect(backend.Name()).Should(Equal("local:tapas-wtq.onnx"))
+		Expect(backend.Close()).Should(Succeed())
+		Expect(session.closed).Should(BeTrue())
+
+		_, err = (&main.LocalBackend{Session: &fakeSession{err: errors.New("bad input")}}).Ask(context.Background(), main.Inputs{Table: table, Query: "Which?"})
+		Expect(err).Should(MatchError(main.ErrLocalModel))
+		_, err = (&main.LocalBackend{Session: &fakeSession{short: true}}).Ask(context.Background(), main.Inputs{Table: table, Query: "Which?"})
+		Expect(err).Should(MatchError(ContainSubstring("logits for")))
+	})
+})
@@ -0,0 +1,201 @@
+package main
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// tapasVocabFile vocabulary wordpiece TAPAS (sama dengan bert-base-uncased, dengan [EMPTY] untuk cell kosong)
+//
+//go:embed tapas-vocab.txt
+var tapasVocabFile string
+
+// TapasMaxTokens panjang input maksimal model TAPAS; token setelahnya dipotong oleh model
+const TapasMaxTokens = 512
+
+// ErrTableTooLarge dikembalikan jika tabel tidak muat di model walaupun sudah diringkas
+var ErrTableTooLarge = messageError("error.table_too_large")
+
+const (
+	maxWordPieceChars = 100
+	unknownToken      = "[UNK]"
+)
+
+// WordPieceTokenizer struct tokenizer BERT/TAPAS: pemisahan dasar lalu wordpiece terpanjang lebih dulu
+type WordPieceTokenizer struct {
+	// vocab memetakan token ke id-nya, yaitu nomor baris di file vocab
+	vocab map[string]int64
+}
+
+// NewWordPieceTokenizer membuat tokenizer dari file vocab, satu token per baris
+func NewWordPieceTokenizer(vocab string) *WordPieceTokenizer {
+	t := &WordPieceTokenizer{vocab: make(map[string]int64)}
+	for id, token := range strings.Split(vocab, "\n") {
+		if token = strings.TrimRight(token, "\r"); token != "" {
+			t.vocab[token] = int64(id)
+		}
+	}
+	return t
+}
+
+// IDs mengubah token menjadi id vocab; token yang tidak dikenal menjadi id [UNK]
+func (t *WordPieceTokenizer) IDs(tokens []string) []int64 {
+	ids := make([]int64, len(tokens))
+	for i, token := range tokens {
+		id, ok := t.vocab[token]
+		if !ok {
+			id = t.vocab[unknownToken]
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+var (
+	tapasTokenizer     *WordPieceTokenizer
+	tapasTokenizerOnce sync.Once
+)
+
+// TapasTokenizer tokenizer dengan vocab TAPAS yang ikut di-embed, dibuat sekali saat pertama dipakai
+func TapasTokenizer() *WordPieceTokenizer {
+	tapasTokenizerOnce.Do(func() {
+		tapasTokenizer = NewWordPieceTokenizer(tapasVocabFile)
+	})
+	return tapasTokenizer
+}
+
+// Tokenize memecah teks menjadi token wordpiece, misalnya "Refrigerator" menjadi [refrigerator]
+// dan "kWh" menjadi [kw ##h]
+func (t *WordPieceTokenizer) Tokenize(text string) []string {
+	var tokens []string
+	for _, word := range basicTokenize(text) {
+		tokens = append(tokens, t.wordPiece(word)...)
+	}
+	return tokens
+}
+
+// basicTokenize huruf kecil, buang aksen, lalu pisahkan di spasi dan tanda baca seperti BasicTokenizer BERT
+func basicTokenize(text string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == 0, r == unicode.ReplacementChar, unicode.IsControl(r) && !unicode.IsSpace(r):
+		case unicode.IsSpace(r):
+			flush()
+		case isBertPunctuation(r) || unicode.Is(unicode.Han, r):
+			flush()
+			words = append(words, string(r))
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// isBertPunctuation semua simbol ASCII non-alfanumerik dianggap tanda baca, seperti di BERT
+func isBertPunctuation(r rune) bool {
+	if r >= 33 && r <= 47 || r >= 58 && r <= 64 || r >= 91 && r <= 96 || r >= 123 && r <= 126 {
+		return true
+	}
+	return unicode.IsPunct(r)
+}
+
+// wordPiece memecah satu kata dengan mencari awalan terpanjang yang ada di vocab
+func (t *WordPieceTokenizer) wordPiece(word string) []string {
+	runes := []rune(word)
+	if len(runes) > maxWordPieceChars {
+		return []string{unknownToken}
+	}
+	var pieces []string
+	for start := 0; start < len(runes); {
+		end := len(runes)
+		piece := ""
+		for ; end > start; end-- {
+			candidate := string(runes[start:end])
+			if start > 0 {
+				candidate = "##" + candidate
+			}
+			if _, ok := t.vocab[candidate]; ok {
+				piece = candidate
+				break
+			}
+		}
+		if piece == "" {
+			return []string{unknownToken}
+		}
+		pieces = append(pieces, piece)
+		start = end
+	}
+	return pieces
+}
+
+// TokenEstimate struct perkiraan jumlah token satu request TAPAS
+type TokenEstimate struct {
+	// Query token pertanyaan termasuk [CLS] dan [SEP]
+	Query  int `json:"query"`
+	Header int `json:"header"`
+	Cells  int `json:"cells"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	// Rows jumlah baris tabel, RowsFit jumlah baris pertama yang masih muat dalam Limit
+	Rows      int  `json:"rows"`
+	RowsFit   int  `json:"rows_fit"`
+	Truncated bool `json:"truncated"`
+}
+
+// EstimateTokens menghitung token yang akan dipakai inputs dengan urutan yang sama seperti
+// TapasTokenizer: [CLS] pertanyaan [SEP], nama kolom, lalu cell baris per baris. Cell kosong
+// menjadi satu token [EMPTY]. limit 0 berarti TapasMaxTokens.
+func EstimateTokens(inputs Inputs, limit int) TokenEstimate {
+	if limit <= 0 {
+		limit = TapasMaxTokens
+	}
+	tokenizer := TapasTokenizer()
+	columns := sortedColumns(inputs.Table)
+	e := TokenEstimate{Limit: limit, Rows: tableRows(inputs.Table)}
+	e.Query = len(tokenizer.Tokenize(inputs.Query)) + 2
+	for _, col := range columns {
+		e.Header += len(tokenizer.Tokenize(col))
+	}
+
+	// Nilai cell banyak berulang (nama ruangan, jam), jadi hasil tokenisasi disimpan
+	counts := make(map[string]int)
+	used := e.Query + e.Header
+	for row := 0; row < e.Rows; row++ {
+		rowTokens := 0
+		for _, col := range columns {
+			var cell string
+			if cells := inputs.Table[col]; row < len(cells) {
+				cell = cells[row]
+			}
+			n, ok := counts[cell]
+			if !ok {
+				n = len(tokenizer.Tokenize(cell))
+				if n == 0 {
+					n = 1 // [EMPTY]
+				}
+				counts[cell] = n
+			}
+			rowTokens += n
+		}
+		e.Cells += rowTokens
+		if used += rowTokens; used <= limit {
+			e.RowsFit++
+		}
+	}
+	e.Total = e.Query + e.Header + e.Cells
+	e.Truncated = e.Total > limit
+	return e
+}
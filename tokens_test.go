@@ -0,0 +1,70 @@
+package main_test
+
+import (
+	"context"
+	"io/ioutil"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token estimator", func() {
+	It("tokenizes like the TAPAS wordpiece tokenizer", func() {
+		tokenizer := main.TapasTokenizer()
+		Expect(tokenizer.Tokenize("Energy_Consumption")).Should(Equal([]string{"energy", "_", "consumption"}))
+		Expect(tokenizer.Tokenize("kWh")).Should(Equal([]string{"kw", "##h"}))
+		Expect(tokenizer.Tokenize("Café, 2023-06-01")).Should(Equal([]string{"cafe", ",", "202", "##3", "-", "06", "-", "01"}))
+		Expect(tokenizer.Tokenize("")).Should(BeEmpty())
+	})
+
+	It("counts the query, header and cells with [EMPTY] for empty cells", func() {
+		e := main.EstimateTokens(main.Inputs{
+			Query: "how much energy?",
+			Table: map[string][]string{"Room": {"Kitchen", ""}, "Energy_Consumption": {"1.2", "0.5"}},
+		}, 0)
+		// [CLS] how much energy ? [SEP]
+		Expect(e.Query).Should(Equal(6))
+		Expect(e.Header).Should(Equal(4))
+		// 1 . 2 | kitchen, 0 . 5 | [EMPTY]
+		Expect(e.Cells).Should(Equal(8))
+		Expect(e.Total).Should(Equal(18))
+		Expect(e.Limit).Should(Equal(main.TapasMaxTokens))
+		Expect(e.RowsFit).Should(Equal(2))
+		Expect(e.Truncated).Should(BeFalse())
+	})
+
+	It("predicts how many rows fit before truncation", func() {
+		data, err := ioutil.ReadFile("data-series.csv")
+		Expect(err).ShouldNot(HaveOccurred())
+		table, err := main.CsvToSlice(string(data))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		e := main.EstimateTokens(main.Inputs{Query: "What is the total energy consumption?", Table: table}, 100)
+		Expect(e.Truncated).Should(BeTrue())
+		Expect(e.RowsFit).Should(BeNumerically(">", 0))
+		Expect(e.RowsFit).Should(BeNumerically("<", e.Rows))
+	})
+
+	It("summarizes or rejects tables that would be truncated", func() {
+		table := map[string][]string{
+			"Date":               {"2023-06-01", "2023-06-01", "2023-06-01", "2023-06-02", "2023-06-02", "2023-06-02"},
+			"Appliance":          {"TV", "Fridge", "Heater", "TV", "Fridge", "Heater"},
+			"Energy_Consumption": {"0.5", "1.2", "2.3", "0.7", "1.1", "2.9"},
+		}
+		backend := &fakeBackend{response: main.Response{Answer: "1", Coordinates: [][]int{{0, 1}}, Cells: []string{"4"}, Aggregator: "NONE"}}
+		pipeline := &main.Pipeline{Backend: backend, MaxTokens: 40}
+
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "What is the total energy used?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.PreAggregation).Should(Equal("sum(Energy_Consumption) by Date"))
+		Expect(result.Tokens.Truncated).Should(BeFalse())
+		Expect(result.Explanation.Tokens).ShouldNot(BeNil())
+
+		_, err = pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Which appliance used the most energy on each day?"})
+		Expect(err).Should(MatchError(main.ErrTableTooLarge))
+		Expect(err).Should(MatchError(ContainSubstring("rows fit")))
+		Expect(backend.asked).Should(HaveLen(1))
+	})
+})
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kode bahasa yang didukung
+const (
+	LangEnglish    = "en"
+	LangIndonesian = "id"
+)
+
+// QueryTranslator interface untuk menerjemahkan pertanyaan ke bahasa Inggris sebelum dikirim ke TAPAS
+type QueryTranslator interface {
+	ToEnglish(ctx context.Context, query string) (string, error)
+}
+
+var indonesianMarkers = map[string]bool{
+	"berapa": true, "apa": true, "apakah": true, "kapan": true, "bagaimana": true, "mana": true,
+	"yang": true, "dan": true, "di": true, "pada": true, "dari": true, "antara": true, "dengan": true,
+	"bulan": true, "tahun": true, "tanggal": true, "hari": true, "minggu": true,
+	"konsumsi": true, "energi": true, "daya": true, "listrik": true, "pemakaian": true, "penggunaan": true,
+	"rata-rata": true, "jumlah": true, "tertinggi": true, "terendah": true,
+	"bandingkan": true, "perbandingan": true, "ruangan": true, "dapur": true, "kamar": true,
+	"januari": true, "februari": true, "maret": true, "mei": true, "juni": true, "juli": true,
+	"agustus": true, "oktober": true, "desember": true, "tercatat": true, "selama": true,
+}
+
+var englishMarkers = map[string]bool{
+	"what": true, "how": true, "when": true, "which": true, "is": true, "was": true, "the": true,
+	"of": true, "in": true, "on": true, "and": true, "between": true, "average": true,
+	"consumption": true, "energy": true, "power": true, "much": true, "many": true,
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}-]+`)
+
+// DetectLanguage menebak bahasa pertanyaan dengan menghitung kata penanda
+func DetectLanguage(query string) string {
+	id, en := 0, 0
+	for _, w := range wordPattern.FindAllString(strings.ToLower(query), -1) {
+		if indonesianMarkers[w] {
+			id++
+		}
+		if englishMarkers[w] {
+			en++
+		}
+	}
+	if id > en {
+		return LangIndonesian
+	}
+	return LangEnglish
+}
+
+// Lexicon offline Indonesia -> Inggris. Frasa diproses dari yang terpanjang,
+// sehingga "berapa rata-rata" menang atas "berapa".
+var indonesianPhrases = map[string]string{
+	// kata tanya
+	"berapa rata-rata": "what is the average",
+	"berapa total":     "what is the total",
+	"berapa jumlah":    "what is the total",
+	"berapa banyak":    "how many",
+	"berapa kali":      "how many times",
+	"berapa":           "how much",
+	"apa":              "what is",
+	"apakah":           "is",
+	"kapan":            "when was",
+	"bagaimana":        "how has",
+	"di mana":          "where",
+	"mana":             "which",
+	"bandingkan":       "compare",
+	"perbandingan":     "comparison of",
+	"prediksi":         "predicted",
+	"perkiraan":        "predicted",
+	// kata benda
+	"konsumsi energi":    "energy consumption",
+	"pemakaian energi":   "energy consumption",
+	"penggunaan energi":  "energy consumption",
+	"konsumsi daya":      "power consumption",
+	"pemakaian listrik":  "energy consumption",
+	"penggunaan listrik": "energy consumption",
+	"konsumsi listrik":   "energy consumption",
+	"daya":               "power",
+	"energi":             "energy",
+	"listrik":            "electricity",
+	"tegangan":           "voltage",
+	"konsumsi":           "consumption",
+	"peralatan":          "appliance",
+	"alat":               "appliance",
+	"perangkat":          "appliance",
+	"ruangan":            "room",
+	"status":             "status",
+	"tren":               "trend",
+	"puncak":             "peak",
+	// ruangan
+	"ruang tamu":     "living room",
+	"ruang keluarga": "living room",
+	"kamar tidur":    "bedroom",
+	"kamar mandi":    "bathroom",
+	"ruang cuci":     "laundry room",
+	"dapur":          "kitchen",
+	"garasi":         "garage",
+	// peralatan
+	"kulkas":            "refrigerator",
+	"lemari es":         "refrigerator",
+	"pendingin ruangan": "air conditioner",
+	"mesin cuci":        "washing machine",
+	"televisi":          "television",
+	"lampu":             "lights",
+	"pemanas":           "heater",
+	"oven microwave":    "microwave",
+	// agregasi dan sifat
+	"rata-rata": "average",
+	"jumlah":    "total",
+	"total":     "total",
+	"tertinggi": "highest",
+	"terendah":  "lowest",
+	"terbesar":  "largest",
+	"terkecil":  "smallest",
+	"maksimum":  "maximum",
+	"minimum":   "minimum",
+	"menyala":   "on",
+	"mati":      "off",
+	// waktu
+	"per bulan":        "per month",
+	"per hari":         "per day",
+	"per tahun":        "per year",
+	"setiap bulan":     "per month",
+	"bulan depan":      "next month",
+	"tahun lalu":       "last year",
+	"setahun terakhir": "the past year",
+	"selama":           "over",
+	"bulan":            "in",
+	"pada tanggal":     "on",
+	"tanggal":          "on",
+	"tahun":            "in",
+	"hari":             "day",
+	"harian":           "daily",
+	"bulanan":          "monthly",
+	"tahunan":          "yearly",
+	"januari":          "january",
+	"februari":         "february",
+	"maret":            "march",
+	"april":            "april",
+	"mei":              "may",
+	"juni":             "june",
+	"juli":             "july",
+	"agustus":          "august",
+	"september":        "september",
+	"oktober":          "october",
+	"november":         "november",
+	"desember":         "december",
+	// kata sambung
+	"antara":       "between",
+	"dan":          "and",
+	"atau":         "or",
+	"di":           "in",
+	"pada":         "on",
+	"dari":         "of",
+	"dengan":       "with",
+	"untuk":        "for",
+	"yang":         "that",
+	"dibandingkan": "compared to",
+	"tercatat":     "recorded",
+	"digunakan":    "used",
+	"dikonsumsi":   "consumed",
+	"adalah":       "is",
+	"ini":          "this",
+	"itu":          "that",
+	"telah":        "has",
+	"sudah":        "has",
+}
+
+// Superlatif di bahasa Indonesia ada di belakang kata benda ("konsumsi energi tertinggi"),
+// jadi dipindah ke depan sebelum diterjemahkan.
+var superlativePattern = regexp.MustCompile(`(?i)\b((?:konsumsi|pemakaian|penggunaan) (?:energi|daya|listrik)|daya|tegangan|energi)\s+(tertinggi|terendah|terbesar|terkecil|maksimum|minimum)\b`)
+
+// LexiconTranslator struct penerjemah offline berbasis kamus frasa
+type LexiconTranslator struct {
+	pattern *regexp.Regexp
+}
+
+// NewLexiconTranslator membuat penerjemah dari lexicon bawaan
+func NewLexiconTranslator() *LexiconTranslator {
+	phrases := make([]string, 0, len(indonesianPhrases))
+	for p := range indonesianPhrases {
+		phrases = append(phrases, p)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+	quoted := make([]string, len(phrases))
+	for i, p := range phrases {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	pattern := regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	return &LexiconTranslator{pattern: pattern}
+}
+
+// ToEnglish menerjemahkan pertanyaan Indonesia; pertanyaan bahasa Inggris dikembalikan apa adanya
+func (t *LexiconTranslator) ToEnglish(ctx context.Context, query string) (string, error) {
+	if DetectLanguage(query) != LangIndonesian {
+		return query, nil
+	}
+	text := strings.Join(strings.Fields(query), " ")
+	text = superlativePattern.ReplaceAllString(text, "$2 $1")
+	text = t.pattern.ReplaceAllStringFunc(text, func(m string) string {
+		return indonesianPhrases[strings.ToLower(m)]
+	})
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.Replace(text, " ?", "?", -1)
+	if text != "" {
+		text = strings.ToUpper(text[:1]) + text[1:]
+	}
+	return text, nil
+}
+
+// BuildTranslator membuat penerjemah dari cfg.Translator (lexicon, hf, atau none) sehingga REPL,
+// server, dan eval menerjemahkan pertanyaan dengan cara yang sama. none mengembalikan nil.
+func BuildTranslator(cfg Config) (QueryTranslator, error) {
+	switch cfg.Translator {
+	case "lexicon":
+		return NewLexiconTranslator(), nil
+	case "hf":
+		return &HFTranslator{Client: &http.Client{}, Token: cfg.Model.Token, Model: "Helsinki-NLP/opus-mt-id-en", Fallback: NewLexiconTranslator()}, nil
+	case "none":
+		return nil, nil
+	}
+	return nil, errors.New(T("main.unknown_option", "translator", cfg.Translator))
+}
+
+// HFTranslator struct penerjemah memakai model terjemahan Huggingface, misalnya Helsinki-NLP/opus-mt-id-en
+type HFTranslator struct {
+	Client *http.Client
+	Token  string
+	Model  string
+	// Fallback dipakai jika model tidak bisa dihubungi
+	Fallback QueryTranslator
+}
+
+// ToEnglish mengirim pertanyaan Indonesia ke model terjemahan
+func (t *HFTranslator) ToEnglish(ctx context.Context, query string) (string, error) {
+	if DetectLanguage(query) != LangIndonesian {
+		return query, nil
+	}
+	text, err := t.translate(ctx, query)
+	if err != nil && t.Fallback != nil {
+		return t.Fallback.ToEnglish(ctx, query)
+	}
+	return text, err
+}
+
+func (t *HFTranslator) translate(ctx context.Context, query string) (string, error) {
+	data, err := json.Marshal(map[string]string{"inputs": query})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", ModelURL(t.Model), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", &ModelError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
+	}
+
+	var out []struct {
+		TranslationText string `json:"translation_text"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("%s: %w", T("translate.decode"), err)
+	}
+	if len(out) == 0 || out[0].TranslationText == "" {
+		return "", errors.New(T("translate.empty"))
+	}
+	return out[0].TranslationText, nil
+}
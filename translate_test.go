@@ -0,0 +1,59 @@
+package main_test
+
+import (
+	"context"
+	"time"
+
+	main "a21hc3NpZ25tZW50"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Indonesian queries", func() {
+	translator := main.NewLexiconTranslator()
+
+	DescribeTable("translates to canonical English",
+		func(query, expected string) {
+			Expect(main.DetectLanguage(query)).Should(Equal(main.LangIndonesian))
+			got, err := translator.ToEnglish(context.Background(), query)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(got).Should(Equal(expected))
+		},
+		Entry("monthly average", "Berapa rata-rata konsumsi energi bulan Juni 2023?", "What is the average energy consumption in june 2023?"),
+		Entry("peak", "Kapan konsumsi energi tertinggi tercatat?", "When was highest energy consumption recorded?"),
+		Entry("rooms", "Bandingkan konsumsi energi antara ruang tamu dan dapur.", "Compare energy consumption between living room and kitchen."),
+	)
+
+	It("leaves English questions untouched", func() {
+		query := "What is the average power consumption?"
+		Expect(main.DetectLanguage(query)).Should(Equal(main.LangEnglish))
+		got, err := translator.ToEnglish(context.Background(), query)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(got).Should(Equal(query))
+	})
+
+	It("formats numbers and dates for Indonesian answers", func() {
+		Expect(main.FormatNumber(1444.75, main.LangIndonesian)).Should(Equal("1.444,75"))
+		Expect(main.FormatNumber(2023, main.LangIndonesian)).Should(Equal("2023"))
+		Expect(main.FormatDate(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), main.LangIndonesian)).Should(Equal("1 Juni 2023"))
+		Expect(main.LocalizeText("2023-06-01 17:00 used 1.2", main.LangIndonesian)).Should(Equal("1 Juni 2023 17:00 used 1,2"))
+
+		resp := main.Response{Answer: "AVERAGE > 1.2, 1.5", Cells: []string{"1.2", "1.5"}, Aggregator: "AVERAGE"}
+		Expect(main.FormatAnswer(resp, main.LangIndonesian)).Should(Equal("Rata-rata: 1,35"))
+		Expect(main.FormatAnswer(resp, main.LangEnglish)).Should(Equal("AVERAGE > 1.2, 1.5"))
+	})
+
+	It("sends the translated question and filters by the Indonesian month", func() {
+		backend := &fakeBackend{response: main.Response{Answer: "AVERAGE > 1.2", Cells: []string{"1.2"}, Aggregator: "AVERAGE"}}
+		pipeline := &main.Pipeline{Backend: backend, Translator: translator}
+		table := map[string][]string{"Date": {"2023-05-31", "2023-06-01"}, "Energy_Consumption": {"1.0", "1.2"}}
+
+		result, err := pipeline.Ask(context.Background(), main.Question{Table: table, Query: "Berapa rata-rata konsumsi energi bulan Juni 2023?"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Language).Should(Equal(main.LangIndonesian))
+		Expect(backend.asked[0].Query).Should(Equal("What is the average energy consumption in june 2023?"))
+		Expect(result.RowsSent).Should(Equal(1))
+		Expect(result.Answer).Should(Equal("Rata-rata: 1,2"))
+	})
+})